@@ -22,6 +22,7 @@ func main() {
 			binit.TelegramAPI,
 			binit.ScrapperClient,
 			binit.Telebot,
+			binit.Templates,
 			binit.CircuitBreaker,
 			binit.RoundTripper,
 			binit.BotServer,
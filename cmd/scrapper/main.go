@@ -22,41 +22,16 @@ func main() {
 			sinit.SubsRepository,
 			sinit.TagsRepository,
 			sinit.FiltersRepository,
+			sinit.UpdatesRepository,
 			sinit.Transactor,
 			sinit.Storage,
 			sinit.BotClient,
 			sinit.Serializer,
 			sinit.Limiter,
-			fx.Annotate(
-				sinit.StackClient,
-				fx.ResultTags(`name:"stack"`),
-			),
-			fx.Annotate(
-				sinit.GitHubClient,
-				fx.ResultTags(`name:"github"`),
-			),
+			sinit.SourceClients,
 			sinit.Scheduler,
-			fx.Annotate(
-				sinit.Notifier,
-				fx.ParamTags(
-					"",
-					`name:"github"`,
-					`name:"stack"`,
-					"",
-					"",
-					"",
-				),
-			),
-			fx.Annotate(
-				sinit.Fetcher,
-				fx.ParamTags(
-					"",
-					`name:"github"`,
-					`name:"stack"`,
-					"",
-					"",
-				),
-			),
+			sinit.Notifier,
+			sinit.Fetcher,
 			sinit.Updater,
 			sinit.KafkaUpdateWriter,
 			sinit.UpdatePublisher,
@@ -19,6 +19,8 @@ const (
 const (
 	GitHub        = "github"
 	StackOverflow = "stackoverflow"
+	GitLab        = "gitlab"
+	Feed          = "feed"
 )
 
 const (
@@ -31,6 +33,12 @@ const (
 	KafkaTransport = "kafka"
 )
 
+const (
+	CoordinationNone     = "none"
+	CoordinationRedis    = "redis"
+	CoordinationPostgres = "postgres"
+)
+
 const (
 	ShutdownTimeout = 5 * time.Second
 )
@@ -65,16 +73,46 @@ type (
 	Secrets struct {
 		GitHubToken        string `env:"GITHUB_TOKEN"`
 		StackOverflowToken string `env:"STACKOVERFLOW_TOKEN"`
+		GitLabToken        string `env:"GITLAB_TOKEN"`
 		BotToken           string `env:"BOT_TOKEN"`
 	}
 
+	GitHubAPI struct {
+		BaseURL string `yaml:"baseURL" env:"GITHUB_API_URL" envDefault:"https://api.github.com"`
+	}
+
+	GitLabInstance struct {
+		BaseURL string `yaml:"baseURL" env:"GITLAB_BASE_URL" envDefault:"https://gitlab.com"`
+	}
+
+	StackExchangeAPI struct {
+		BaseURL string `yaml:"baseURL" env:"STACKEXCHANGE_API_URL" envDefault:"https://api.stackexchange.com/2.3"`
+	}
+
+	Feeds struct {
+		AllowPrivateHosts bool `yaml:"allowPrivateHosts" env:"FEEDS_ALLOW_PRIVATE_HOSTS"`
+		CacheSize         int  `yaml:"cacheSize" envDefault:"10000"`
+	}
+
 	Notifier struct {
 		NumWorkers int `yaml:"numWorkers" envDefault:"16"`
+		DigestTop  int `yaml:"digestTop" envDefault:"5"`
 	}
 
 	Updater struct {
-		BatchSize  uint64 `yaml:"batchSize" envDefault:"200"`
-		NumWorkers int    `yaml:"numWorkers" envDefault:"16"`
+		BatchSize     uint64        `yaml:"batchSize" envDefault:"200"`
+		NumWorkers    int           `yaml:"numWorkers" envDefault:"16"`
+		PollInterval  time.Duration `yaml:"pollInterval" envDefault:"30s"`
+		MinInterval   time.Duration `yaml:"minInterval" envDefault:"5m"`
+		MaxInterval   time.Duration `yaml:"maxInterval" envDefault:"6h"`
+		BackoffFactor float64       `yaml:"backoffFactor" envDefault:"2"`
+		LeaseDuration time.Duration `yaml:"leaseDuration" envDefault:"10m"`
+	}
+
+	Scheduler struct {
+		Coordination string        `yaml:"coordination" envDefault:"none"`
+		LockTTL      time.Duration `yaml:"lockTTL" envDefault:"1m"`
+		ElectionKey  int64         `yaml:"electionKey" envDefault:"7460201"`
 	}
 
 	Delivery struct {
@@ -117,9 +155,14 @@ type Config struct {
 	Database             Database             `yaml:"database"`
 	Brokers              Brokers              `yaml:"brokers"`
 	Cache                Cache                `yaml:"cache"`
+	GitHub               GitHubAPI            `yaml:"github"`
+	GitLab               GitLabInstance       `yaml:"gitlab"`
+	StackExchange        StackExchangeAPI     `yaml:"stackexchange"`
+	Feeds                Feeds                `yaml:"feeds"`
 	Delivery             Delivery             `yaml:"delivery"`
 	Updater              Updater              `yaml:"updater"`
 	Notifier             Notifier             `yaml:"notifier"`
+	Scheduler            Scheduler            `yaml:"scheduler"`
 	TimeoutPolicy        Timeouts             `yaml:"timeout"`
 	RetryPolicy          RetryPolicy          `yaml:"retry"`
 	RateLimiter          RateLimiter          `yaml:"rateLimiter"`
@@ -216,6 +259,18 @@ var Descriptions = []struct {
 		Name:        "/list",
 		Description: "prints the list of a tracking links",
 	},
+	{
+		Name:        "/schedule",
+		Description: "sets when the digest is delivered",
+	},
+	{
+		Name:        "/timezone",
+		Description: "sets the timezone of the digest schedule",
+	},
+	{
+		Name:        "/template",
+		Description: "sets the layout of updates, sent instantly or in a digest",
+	},
 	{
 		Name:        "/cancel",
 		Description: "return the user to the menu",
@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 )
 
 // ApiErrorResponse defines model for ApiErrorResponse.
@@ -21,13 +22,50 @@ type ApiErrorResponse struct {
 	ErrorMessage string `json:"errorMessage"`
 }
 
+// ChatDigest defines model for ChatDigest.
+type ChatDigest struct {
+	Links    []LinkDigest `json:"links"`
+	TgChatId int64        `json:"tgChatId"`
+}
+
+// KindCount defines model for KindCount.
+type KindCount struct {
+	Count int    `json:"count"`
+	Kind  string `json:"kind"`
+}
+
+// LinkDigest defines model for LinkDigest.
+type LinkDigest struct {
+	Counts  []KindCount `json:"counts"`
+	Total   int         `json:"total"`
+	Updates []Update    `json:"updates"`
+	Url     string      `json:"url"`
+}
+
 // LinkUpdate defines model for LinkUpdate.
 type LinkUpdate struct {
-	Description string `json:"description"`
-	TgChatId    int64  `json:"tgChatId"`
-	Url         string `json:"url"`
+	TgChatId int64  `json:"tgChatId"`
+	Update   Update `json:"update"`
+	Url      string `json:"url"`
+}
+
+// Update defines model for Update.
+type Update struct {
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"createdAt"`
+	Id        string    `json:"id"`
+	Kind      string    `json:"kind"`
+	Labels    []string  `json:"labels"`
+	Source    string    `json:"source"`
+	Title     string    `json:"title"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	Url       string    `json:"url"`
 }
 
+// PostDigestsJSONRequestBody defines body for PostDigests for application/json ContentType.
+type PostDigestsJSONRequestBody = ChatDigest
+
 // PostUpdatesJSONRequestBody defines body for PostUpdates for application/json ContentType.
 type PostUpdatesJSONRequestBody = LinkUpdate
 
@@ -104,12 +142,41 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// PostDigestsWithBody request with any body
+	PostDigestsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostDigests(ctx context.Context, body PostDigestsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// PostUpdatesWithBody request with any body
 	PostUpdatesWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	PostUpdates(ctx context.Context, body PostUpdatesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
+func (c *Client) PostDigestsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostDigestsRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostDigests(ctx context.Context, body PostDigestsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostDigestsRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) PostUpdatesWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewPostUpdatesRequestWithBody(c.Server, contentType, body)
 	if err != nil {
@@ -134,6 +201,46 @@ func (c *Client) PostUpdates(ctx context.Context, body PostUpdatesJSONRequestBod
 	return c.Client.Do(req)
 }
 
+// NewPostDigestsRequest calls the generic PostDigests builder with application/json body
+func NewPostDigestsRequest(server string, body PostDigestsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostDigestsRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostDigestsRequestWithBody generates requests for PostDigests with any type of body
+func NewPostDigestsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/digests")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
 // NewPostUpdatesRequest calls the generic PostUpdates builder with application/json body
 func NewPostUpdatesRequest(server string, body PostUpdatesJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
@@ -217,16 +324,45 @@ func WithBaseURL(baseURL string) ClientOption {
 
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
+	// PostDigestsWithBodyWithResponse request with any body
+	PostDigestsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostDigestsResponse, error)
+
+	PostDigestsWithResponse(ctx context.Context, body PostDigestsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostDigestsResponse, error)
+
 	// PostUpdatesWithBodyWithResponse request with any body
 	PostUpdatesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUpdatesResponse, error)
 
 	PostUpdatesWithResponse(ctx context.Context, body PostUpdatesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostUpdatesResponse, error)
 }
 
+type PostDigestsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *ApiErrorResponse
+	JSON502      *ApiErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostDigestsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostDigestsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type PostUpdatesResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
 	JSON400      *ApiErrorResponse
+	JSON502      *ApiErrorResponse
 }
 
 // Status returns HTTPResponse.Status
@@ -245,6 +381,23 @@ func (r PostUpdatesResponse) StatusCode() int {
 	return 0
 }
 
+// PostDigestsWithBodyWithResponse request with arbitrary body returning *PostDigestsResponse
+func (c *ClientWithResponses) PostDigestsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostDigestsResponse, error) {
+	rsp, err := c.PostDigestsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostDigestsResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostDigestsWithResponse(ctx context.Context, body PostDigestsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostDigestsResponse, error) {
+	rsp, err := c.PostDigests(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostDigestsResponse(rsp)
+}
+
 // PostUpdatesWithBodyWithResponse request with arbitrary body returning *PostUpdatesResponse
 func (c *ClientWithResponses) PostUpdatesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUpdatesResponse, error) {
 	rsp, err := c.PostUpdatesWithBody(ctx, contentType, body, reqEditors...)
@@ -262,6 +415,39 @@ func (c *ClientWithResponses) PostUpdatesWithResponse(ctx context.Context, body
 	return ParsePostUpdatesResponse(rsp)
 }
 
+// ParsePostDigestsResponse parses an HTTP response from a PostDigestsWithResponse call
+func ParsePostDigestsResponse(rsp *http.Response) (*PostDigestsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostDigestsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ApiErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest ApiErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParsePostUpdatesResponse parses an HTTP response from a PostUpdatesWithResponse call
 func ParsePostUpdatesResponse(rsp *http.Response) (*PostUpdatesResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -283,6 +469,13 @@ func ParsePostUpdatesResponse(rsp *http.Response) (*PostUpdatesResponse, error)
 		}
 		response.JSON400 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest ApiErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
 	}
 
 	return response, nil
@@ -16,11 +16,18 @@ import (
 	"github.com/oapi-codegen/runtime"
 )
 
+// Defines values for DeliveryMode.
+const (
+	Digest  DeliveryMode = "digest"
+	Instant DeliveryMode = "instant"
+)
+
 // AddLinkRequest defines model for AddLinkRequest.
 type AddLinkRequest struct {
-	Filters []string `json:"filters"`
-	Link    string   `json:"link"`
-	Tags    []string `json:"tags"`
+	Filters []string      `json:"filters"`
+	Link    string        `json:"link"`
+	Mode    *DeliveryMode `json:"mode,omitempty"`
+	Tags    []string      `json:"tags"`
 }
 
 // ApiErrorResponse defines model for ApiErrorResponse.
@@ -30,6 +37,15 @@ type ApiErrorResponse struct {
 	ErrorMessage string `json:"errorMessage"`
 }
 
+// DeliveryMode defines model for DeliveryMode.
+type DeliveryMode string
+
+// DigestResponse defines model for DigestResponse.
+type DigestResponse struct {
+	Schedule string `json:"schedule"`
+	Timezone string `json:"timezone"`
+}
+
 // LinkResponse defines model for LinkResponse.
 type LinkResponse struct {
 	Filters []string `json:"filters"`
@@ -49,6 +65,22 @@ type RemoveLinkRequest struct {
 	Link string `json:"link"`
 }
 
+// TemplateResponse defines model for TemplateResponse.
+type TemplateResponse struct {
+	Template string `json:"template"`
+}
+
+// UpdateDigestRequest defines model for UpdateDigestRequest.
+type UpdateDigestRequest struct {
+	Schedule *string `json:"schedule,omitempty"`
+	Timezone *string `json:"timezone,omitempty"`
+}
+
+// UpdateTemplateRequest defines model for UpdateTemplateRequest.
+type UpdateTemplateRequest struct {
+	Template string `json:"template"`
+}
+
 // DeleteLinksParams defines parameters for DeleteLinks.
 type DeleteLinksParams struct {
 	TgChatId int64 `json:"Tg-Chat-Id"`
@@ -70,6 +102,12 @@ type DeleteLinksJSONRequestBody = RemoveLinkRequest
 // PostLinksJSONRequestBody defines body for PostLinks for application/json ContentType.
 type PostLinksJSONRequestBody = AddLinkRequest
 
+// PutTgChatIdDigestJSONRequestBody defines body for PutTgChatIdDigest for application/json ContentType.
+type PutTgChatIdDigestJSONRequestBody = UpdateDigestRequest
+
+// PutTgChatIdTemplateJSONRequestBody defines body for PutTgChatIdTemplate for application/json ContentType.
+type PutTgChatIdTemplateJSONRequestBody = UpdateTemplateRequest
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -164,6 +202,22 @@ type ClientInterface interface {
 
 	// PostTgChatId request
 	PostTgChatId(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTgChatIdDigest request
+	GetTgChatIdDigest(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutTgChatIdDigestWithBody request with any body
+	PutTgChatIdDigestWithBody(ctx context.Context, id int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutTgChatIdDigest(ctx context.Context, id int64, body PutTgChatIdDigestJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTgChatIdTemplate request
+	GetTgChatIdTemplate(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutTgChatIdTemplateWithBody request with any body
+	PutTgChatIdTemplateWithBody(ctx context.Context, id int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutTgChatIdTemplate(ctx context.Context, id int64, body PutTgChatIdTemplateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
 func (c *Client) DeleteLinksWithBody(ctx context.Context, params *DeleteLinksParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -262,6 +316,78 @@ func (c *Client) PostTgChatId(ctx context.Context, id int64, reqEditors ...Reque
 	return c.Client.Do(req)
 }
 
+func (c *Client) GetTgChatIdDigest(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTgChatIdDigestRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutTgChatIdDigestWithBody(ctx context.Context, id int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutTgChatIdDigestRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutTgChatIdDigest(ctx context.Context, id int64, body PutTgChatIdDigestJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutTgChatIdDigestRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetTgChatIdTemplate(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTgChatIdTemplateRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutTgChatIdTemplateWithBody(ctx context.Context, id int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutTgChatIdTemplateRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutTgChatIdTemplate(ctx context.Context, id int64, body PutTgChatIdTemplateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutTgChatIdTemplateRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 // NewDeleteLinksRequest calls the generic DeleteLinks builder with application/json body
 func NewDeleteLinksRequest(server string, params *DeleteLinksParams, body DeleteLinksJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
@@ -510,6 +636,168 @@ func NewPostTgChatIdRequest(server string, id int64) (*http.Request, error) {
 	return req, nil
 }
 
+// NewGetTgChatIdDigestRequest generates requests for GetTgChatIdDigest
+func NewGetTgChatIdDigestRequest(server string, id int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tg-chat/%s/digest", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutTgChatIdDigestRequest calls the generic PutTgChatIdDigest builder with application/json body
+func NewPutTgChatIdDigestRequest(server string, id int64, body PutTgChatIdDigestJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutTgChatIdDigestRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewPutTgChatIdDigestRequestWithBody generates requests for PutTgChatIdDigest with any type of body
+func NewPutTgChatIdDigestRequestWithBody(server string, id int64, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tg-chat/%s/digest", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetTgChatIdTemplateRequest generates requests for GetTgChatIdTemplate
+func NewGetTgChatIdTemplateRequest(server string, id int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tg-chat/%s/template", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutTgChatIdTemplateRequest calls the generic PutTgChatIdTemplate builder with application/json body
+func NewPutTgChatIdTemplateRequest(server string, id int64, body PutTgChatIdTemplateJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutTgChatIdTemplateRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewPutTgChatIdTemplateRequestWithBody generates requests for PutTgChatIdTemplate with any type of body
+func NewPutTgChatIdTemplateRequestWithBody(server string, id int64, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tg-chat/%s/template", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
 	for _, r := range c.RequestEditors {
 		if err := r(ctx, req); err != nil {
@@ -574,6 +862,22 @@ type ClientWithResponsesInterface interface {
 
 	// PostTgChatIdWithResponse request
 	PostTgChatIdWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*PostTgChatIdResponse, error)
+
+	// GetTgChatIdDigestWithResponse request
+	GetTgChatIdDigestWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetTgChatIdDigestResponse, error)
+
+	// PutTgChatIdDigestWithBodyWithResponse request with any body
+	PutTgChatIdDigestWithBodyWithResponse(ctx context.Context, id int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutTgChatIdDigestResponse, error)
+
+	PutTgChatIdDigestWithResponse(ctx context.Context, id int64, body PutTgChatIdDigestJSONRequestBody, reqEditors ...RequestEditorFn) (*PutTgChatIdDigestResponse, error)
+
+	// GetTgChatIdTemplateWithResponse request
+	GetTgChatIdTemplateWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetTgChatIdTemplateResponse, error)
+
+	// PutTgChatIdTemplateWithBodyWithResponse request with any body
+	PutTgChatIdTemplateWithBodyWithResponse(ctx context.Context, id int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutTgChatIdTemplateResponse, error)
+
+	PutTgChatIdTemplateWithResponse(ctx context.Context, id int64, body PutTgChatIdTemplateJSONRequestBody, reqEditors ...RequestEditorFn) (*PutTgChatIdTemplateResponse, error)
 }
 
 type DeleteLinksResponse struct {
@@ -713,6 +1017,102 @@ func (r PostTgChatIdResponse) StatusCode() int {
 	return 0
 }
 
+type GetTgChatIdDigestResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *DigestResponse
+	JSON400      *ApiErrorResponse
+	JSON404      *ApiErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTgChatIdDigestResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTgChatIdDigestResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutTgChatIdDigestResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *DigestResponse
+	JSON400      *ApiErrorResponse
+	JSON404      *ApiErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PutTgChatIdDigestResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutTgChatIdDigestResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTgChatIdTemplateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TemplateResponse
+	JSON400      *ApiErrorResponse
+	JSON404      *ApiErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTgChatIdTemplateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTgChatIdTemplateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutTgChatIdTemplateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TemplateResponse
+	JSON400      *ApiErrorResponse
+	JSON404      *ApiErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PutTgChatIdTemplateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutTgChatIdTemplateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 // DeleteLinksWithBodyWithResponse request with arbitrary body returning *DeleteLinksResponse
 func (c *ClientWithResponses) DeleteLinksWithBodyWithResponse(ctx context.Context, params *DeleteLinksParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*DeleteLinksResponse, error) {
 	rsp, err := c.DeleteLinksWithBody(ctx, params, contentType, body, reqEditors...)
@@ -783,6 +1183,58 @@ func (c *ClientWithResponses) PostTgChatIdWithResponse(ctx context.Context, id i
 	return ParsePostTgChatIdResponse(rsp)
 }
 
+// GetTgChatIdDigestWithResponse request returning *GetTgChatIdDigestResponse
+func (c *ClientWithResponses) GetTgChatIdDigestWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetTgChatIdDigestResponse, error) {
+	rsp, err := c.GetTgChatIdDigest(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTgChatIdDigestResponse(rsp)
+}
+
+// PutTgChatIdDigestWithBodyWithResponse request with arbitrary body returning *PutTgChatIdDigestResponse
+func (c *ClientWithResponses) PutTgChatIdDigestWithBodyWithResponse(ctx context.Context, id int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutTgChatIdDigestResponse, error) {
+	rsp, err := c.PutTgChatIdDigestWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutTgChatIdDigestResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutTgChatIdDigestWithResponse(ctx context.Context, id int64, body PutTgChatIdDigestJSONRequestBody, reqEditors ...RequestEditorFn) (*PutTgChatIdDigestResponse, error) {
+	rsp, err := c.PutTgChatIdDigest(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutTgChatIdDigestResponse(rsp)
+}
+
+// GetTgChatIdTemplateWithResponse request returning *GetTgChatIdTemplateResponse
+func (c *ClientWithResponses) GetTgChatIdTemplateWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetTgChatIdTemplateResponse, error) {
+	rsp, err := c.GetTgChatIdTemplate(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTgChatIdTemplateResponse(rsp)
+}
+
+// PutTgChatIdTemplateWithBodyWithResponse request with arbitrary body returning *PutTgChatIdTemplateResponse
+func (c *ClientWithResponses) PutTgChatIdTemplateWithBodyWithResponse(ctx context.Context, id int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutTgChatIdTemplateResponse, error) {
+	rsp, err := c.PutTgChatIdTemplateWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutTgChatIdTemplateResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutTgChatIdTemplateWithResponse(ctx context.Context, id int64, body PutTgChatIdTemplateJSONRequestBody, reqEditors ...RequestEditorFn) (*PutTgChatIdTemplateResponse, error) {
+	rsp, err := c.PutTgChatIdTemplate(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutTgChatIdTemplateResponse(rsp)
+}
+
 // ParseDeleteLinksResponse parses an HTTP response from a DeleteLinksWithResponse call
 func ParseDeleteLinksResponse(rsp *http.Response) (*DeleteLinksResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -973,3 +1425,163 @@ func ParsePostTgChatIdResponse(rsp *http.Response) (*PostTgChatIdResponse, error
 
 	return response, nil
 }
+
+// ParseGetTgChatIdDigestResponse parses an HTTP response from a GetTgChatIdDigestWithResponse call
+func ParseGetTgChatIdDigestResponse(rsp *http.Response) (*GetTgChatIdDigestResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTgChatIdDigestResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest DigestResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ApiErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ApiErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutTgChatIdDigestResponse parses an HTTP response from a PutTgChatIdDigestWithResponse call
+func ParsePutTgChatIdDigestResponse(rsp *http.Response) (*PutTgChatIdDigestResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutTgChatIdDigestResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest DigestResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ApiErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ApiErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetTgChatIdTemplateResponse parses an HTTP response from a GetTgChatIdTemplateWithResponse call
+func ParseGetTgChatIdTemplateResponse(rsp *http.Response) (*GetTgChatIdTemplateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTgChatIdTemplateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TemplateResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ApiErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ApiErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutTgChatIdTemplateResponse parses an HTTP response from a PutTgChatIdTemplateWithResponse call
+func ParsePutTgChatIdTemplateResponse(rsp *http.Response) (*PutTgChatIdTemplateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutTgChatIdTemplateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TemplateResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ApiErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ApiErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
@@ -10,4 +10,5 @@ var (
 	ErrMissingURL         = botapiError{msg: "error: url parameter is missing"}
 	ErrUnknownURL         = botapiError{msg: "error: unknown url"}
 	ErrBotUpdates         = botapiError{msg: "Некорректные параметры запроса"}
+	ErrBotDelivery        = botapiError{msg: "Сообщение не доставлено в Telegram"}
 )
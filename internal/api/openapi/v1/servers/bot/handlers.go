@@ -1,24 +1,33 @@
 package botapi
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/render"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
 type API struct {
-	tc Sender
+	tc        Sender
+	templates Templates
 }
 
 type Sender interface {
 	Send(c tgbotapi.Chattable) (tgbotapi.Message, error)
 }
 
-func New(client *tgbotapi.BotAPI) *API {
+type Templates interface {
+	ForChat(ctx context.Context, chatID int64) string
+}
+
+func New(client *tgbotapi.BotAPI, templates Templates) *API {
 	return &API{
-		tc: client,
+		tc:        client,
+		templates: templates,
 	}
 }
 
@@ -29,10 +38,80 @@ func (a *API) PostUpdates(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if _, err := a.tc.Send(tgbotapi.NewMessage(
-		params.TgChatId,
-		fmt.Sprintf("✨ New update via %s!\n\n %s", params.Url, params.Description))); err != nil {
+	template := a.templates.ForChat(r.Context(), params.TgChatId)
+
+	if err := a.send(params.TgChatId, render.WithTemplate(template, params.Url, toModel(params.Update))); err != nil {
+		respondWithError(w, http.StatusBadGateway, err.Error(), ErrBotDelivery.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, http.NoBody)
+}
+
+func (a *API) PostDigests(w http.ResponseWriter, r *http.Request) {
+	var params ChatDigest
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), ErrBotUpdates.Error())
+		return
+	}
+
+	digest := models.ChatDigest{
+		ChatID: params.TgChatId,
+		Links:  make([]models.LinkDigest, 0, len(params.Links)),
+	}
+
+	for _, link := range params.Links {
+		summary := models.LinkDigest{
+			URL:     link.Url,
+			Total:   link.Total,
+			Counts:  make([]models.KindCount, 0, len(link.Counts)),
+			Updates: make([]models.Update, 0, len(link.Updates)),
+		}
+
+		for _, count := range link.Counts {
+			summary.Counts = append(summary.Counts, models.KindCount{Kind: models.Kind(count.Kind), Count: count.Count})
+		}
+
+		for _, update := range link.Updates {
+			summary.Updates = append(summary.Updates, toModel(update))
+		}
+
+		digest.Links = append(digest.Links, summary)
+	}
+
+	template := a.templates.ForChat(r.Context(), params.TgChatId)
+
+	if err := a.send(params.TgChatId, render.DigestWithTemplate(template, digest)); err != nil {
+		respondWithError(w, http.StatusBadGateway, err.Error(), ErrBotDelivery.Error())
+		return
 	}
 
 	respondWithJSON(w, http.StatusOK, http.NoBody)
 }
+
+// send delivers the messages in order and stops at the first one Telegram refuses,
+// so the caller learns the delivery failed and can retry it another way.
+func (a *API) send(chatID int64, texts []string) error {
+	for _, text := range texts {
+		if _, err := a.tc.Send(message(chatID, text)); err != nil {
+			return fmt.Errorf("botapi: failed to send message: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func toModel(update Update) models.Update {
+	return models.Update{
+		ID:        update.Id,
+		Source:    update.Source,
+		Kind:      models.Kind(update.Kind),
+		Title:     update.Title,
+		URL:       update.Url,
+		Author:    update.Author,
+		Labels:    update.Labels,
+		Body:      update.Body,
+		CreatedAt: update.CreatedAt,
+		UpdatedAt: update.UpdatedAt,
+	}
+}
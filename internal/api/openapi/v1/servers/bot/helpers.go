@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
 func respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
@@ -25,3 +27,11 @@ func respondWithError(w http.ResponseWriter, code int, msg, description string)
 
 	respondWithJSON(w, code, err)
 }
+
+func message(chatID int64, text string) tgbotapi.MessageConfig {
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = tgbotapi.ModeHTML
+	msg.DisableWebPagePreview = true
+
+	return msg
+}
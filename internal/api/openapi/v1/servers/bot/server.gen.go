@@ -8,6 +8,7 @@ package botapi
 import (
 	"fmt"
 	"net/http"
+	"time"
 )
 
 // ApiErrorResponse defines model for ApiErrorResponse.
@@ -17,18 +18,58 @@ type ApiErrorResponse struct {
 	ErrorMessage string `json:"errorMessage"`
 }
 
+// ChatDigest defines model for ChatDigest.
+type ChatDigest struct {
+	Links    []LinkDigest `json:"links"`
+	TgChatId int64        `json:"tgChatId"`
+}
+
+// KindCount defines model for KindCount.
+type KindCount struct {
+	Count int    `json:"count"`
+	Kind  string `json:"kind"`
+}
+
+// LinkDigest defines model for LinkDigest.
+type LinkDigest struct {
+	Counts  []KindCount `json:"counts"`
+	Total   int         `json:"total"`
+	Updates []Update    `json:"updates"`
+	Url     string      `json:"url"`
+}
+
 // LinkUpdate defines model for LinkUpdate.
 type LinkUpdate struct {
-	Description string `json:"description"`
-	TgChatId    int64  `json:"tgChatId"`
-	Url         string `json:"url"`
+	TgChatId int64  `json:"tgChatId"`
+	Update   Update `json:"update"`
+	Url      string `json:"url"`
+}
+
+// Update defines model for Update.
+type Update struct {
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"createdAt"`
+	Id        string    `json:"id"`
+	Kind      string    `json:"kind"`
+	Labels    []string  `json:"labels"`
+	Source    string    `json:"source"`
+	Title     string    `json:"title"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	Url       string    `json:"url"`
 }
 
+// PostDigestsJSONRequestBody defines body for PostDigests for application/json ContentType.
+type PostDigestsJSONRequestBody = ChatDigest
+
 // PostUpdatesJSONRequestBody defines body for PostUpdates for application/json ContentType.
 type PostUpdatesJSONRequestBody = LinkUpdate
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Отправить дайджест чата
+	// (POST /digests)
+	PostDigests(w http.ResponseWriter, r *http.Request)
 	// Отправить обновление
 	// (POST /updates)
 	PostUpdates(w http.ResponseWriter, r *http.Request)
@@ -43,6 +84,20 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
+// PostDigests operation middleware
+func (siw *ServerInterfaceWrapper) PostDigests(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDigests(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // PostUpdates operation middleware
 func (siw *ServerInterfaceWrapper) PostUpdates(w http.ResponseWriter, r *http.Request) {
 
@@ -177,6 +232,7 @@ func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.H
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	m.HandleFunc("POST "+options.BaseURL+"/digests", wrapper.PostDigests)
 	m.HandleFunc("POST "+options.BaseURL+"/updates", wrapper.PostUpdates)
 
 	return m
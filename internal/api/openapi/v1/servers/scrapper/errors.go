@@ -12,6 +12,8 @@ var (
 	ErrLinkNotExists             = scrapperError{msg: "error: link does not exist"}
 	ErrAddLinkInvalidLink        = scrapperError{msg: "error: link is invalid or missing"}
 	ErrAddLinkFailed             = scrapperError{msg: "error: failed to add link to db"}
+	ErrAddLinkInvalidFilters     = scrapperError{msg: "error: filters are invalid"}
+	ErrAddLinkInvalidMode        = scrapperError{msg: "error: delivery mode must be either instant or digest"}
 	ErrGetLinksFailed            = scrapperError{msg: "error: failed to get links"}
 	ErrDeleteLinkInvalidLink     = scrapperError{msg: "error: link is invalid or missing"}
 	ErrAddTagFailed              = scrapperError{msg: "error: failed to "}
@@ -19,4 +21,10 @@ var (
 	ErrSubscriptionsNotExists    = scrapperError{msg: "error: link is not yet being tracked"}
 	ErrTagAlreadyExists          = scrapperError{msg: "error: tag already exists"}
 	ErrFilterAlreadyExists       = scrapperError{msg: "error: filter already exists"}
+	ErrInvalidDigest             = scrapperError{msg: "error: digest timezone or schedule is invalid"}
+	ErrGetDigestFailed           = scrapperError{msg: "error: failed to get digest schedule"}
+	ErrUpdateDigestFailed        = scrapperError{msg: "error: failed to update digest schedule"}
+	ErrInvalidTemplate           = scrapperError{msg: "error: template does not render a sample update"}
+	ErrGetTemplateFailed         = scrapperError{msg: "error: failed to get notification template"}
+	ErrUpdateTemplateFailed      = scrapperError{msg: "error: failed to update notification template"}
 )
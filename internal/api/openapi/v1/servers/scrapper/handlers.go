@@ -5,26 +5,38 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
-	"net/url"
+	"strings"
 
-	"github.com/es-debug/backend-academy-2024-go-template/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/filter"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/render"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/scrapper/digest"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/sources"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
 )
 
 type Storage interface {
 	AddChat(ctx context.Context, chatID int64) error
 	ExistsChat(ctx context.Context, chatID int64) error
 	DeleteChat(ctx context.Context, chatID int64) error
+	GetChatDigest(ctx context.Context, chatID int64) (models.Digest, error)
+	UpdateChatDigest(ctx context.Context, chatID int64, timezone, schedule string) error
+	GetChatTemplate(ctx context.Context, chatID int64) (string, error)
+	UpdateChatTemplate(ctx context.Context, chatID int64, template string) error
 	AddLink(ctx context.Context, link AddLinkRequest, chatID int64) (int64, error)
 	GetLinksWithChat(ctx context.Context, chatID int64) ([]LinkResponse, error)
 	DeleteLink(ctx context.Context, link RemoveLinkRequest, chatID int64) error
 }
 type API struct {
 	storage Storage
+	guarded *http.Client
 }
 
-func New(storage Storage) *API {
+// New builds the API. The guarded client checks links of fallback sources,
+// which may point to any host, and is expected to refuse private addresses.
+func New(storage Storage, guarded *http.Client) *API {
 	return &API{
 		storage: storage,
+		guarded: guarded,
 	}
 }
 
@@ -72,6 +84,107 @@ func (a *API) DeleteTgChatId(w http.ResponseWriter, r *http.Request, id int64) {
 	respondWithJSON(w, http.StatusNoContent, http.NoBody)
 }
 
+//nolint:revive,stylecheck // Generated code cannot be edited.
+func (a *API) GetTgChatIdDigest(w http.ResponseWriter, r *http.Request, id int64) {
+	ctx := r.Context()
+
+	current, err := a.storage.GetChatDigest(ctx, id)
+	if err != nil {
+		respondWithError(w, chatStatus(err), err.Error(), ErrGetDigestFailed.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, DigestResponse{
+		Timezone: current.Timezone,
+		Schedule: current.Schedule,
+	})
+}
+
+//nolint:revive,stylecheck // Generated code cannot be edited.
+func (a *API) PutTgChatIdDigest(w http.ResponseWriter, r *http.Request, id int64) {
+	ctx := r.Context()
+
+	var model UpdateDigestRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&model); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), ErrInvalidBody.Error())
+		return
+	}
+
+	current, err := a.storage.GetChatDigest(ctx, id)
+	if err != nil {
+		respondWithError(w, chatStatus(err), err.Error(), ErrUpdateDigestFailed.Error())
+		return
+	}
+
+	if model.Timezone != nil {
+		current.Timezone = strings.TrimSpace(*model.Timezone)
+	}
+
+	if model.Schedule != nil {
+		current.Schedule = strings.TrimSpace(*model.Schedule)
+	}
+
+	if err := digest.Validate(current.Timezone, current.Schedule); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), ErrInvalidDigest.Error())
+		return
+	}
+
+	if err := a.storage.UpdateChatDigest(ctx, id, current.Timezone, current.Schedule); err != nil {
+		respondWithError(w, chatStatus(err), err.Error(), ErrUpdateDigestFailed.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, DigestResponse{
+		Timezone: current.Timezone,
+		Schedule: current.Schedule,
+	})
+}
+
+//nolint:revive,stylecheck // Generated code cannot be edited.
+func (a *API) GetTgChatIdTemplate(w http.ResponseWriter, r *http.Request, id int64) {
+	ctx := r.Context()
+
+	template, err := a.storage.GetChatTemplate(ctx, id)
+	if err != nil {
+		respondWithError(w, chatStatus(err), err.Error(), ErrGetTemplateFailed.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, TemplateResponse{Template: template})
+}
+
+// PutTgChatIdTemplate stores a template only once it parses within the execution limits
+// and renders a sample update, whichever client sent it. An empty template resets the layout.
+//
+//nolint:revive,stylecheck // Generated code cannot be edited.
+func (a *API) PutTgChatIdTemplate(w http.ResponseWriter, r *http.Request, id int64) {
+	ctx := r.Context()
+
+	var model UpdateTemplateRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&model); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), ErrInvalidBody.Error())
+		return
+	}
+
+	template := strings.TrimSpace(model.Template)
+
+	if template != "" {
+		if _, err := render.ParseTemplate(template); err != nil {
+			respondWithError(w, http.StatusBadRequest, err.Error(), ErrInvalidTemplate.Error())
+			return
+		}
+	}
+
+	if err := a.storage.UpdateChatTemplate(ctx, id, template); err != nil {
+		respondWithError(w, chatStatus(err), err.Error(), ErrUpdateTemplateFailed.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, TemplateResponse{Template: template})
+}
+
 func (a *API) PostLinks(w http.ResponseWriter, r *http.Request, params PostLinksParams) {
 	ctx := r.Context()
 
@@ -84,20 +197,28 @@ func (a *API) PostLinks(w http.ResponseWriter, r *http.Request, params PostLinks
 		return
 	}
 
-	u, err := url.Parse(model.Link)
+	link, err := sources.Canonical(model.Link)
 	if err != nil {
 		respondWithError(w, http.StatusBadRequest, ErrAddLinkInvalidLink.Error(), ErrInvalidBody.Error())
 		return
 	}
 
-	u.Scheme = config.SchemeSecure
+	if err := filter.Validate(model.Filters); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), ErrAddLinkInvalidFilters.Error())
+		return
+	}
+
+	if model.Mode != nil && *model.Mode != Instant && *model.Mode != Digest {
+		respondWithError(w, http.StatusBadRequest, ErrAddLinkInvalidMode.Error(), ErrInvalidBody.Error())
+		return
+	}
 
-	if !isAvailable(u.String()) {
+	if !a.isAvailable(link) {
 		respondWithError(w, http.StatusBadRequest, ErrAddLinkInvalidLink.Error(), ErrInvalidBody.Error())
 		return
 	}
 
-	model.Link = u.String()
+	model.Link = link
 
 	linkID, err := a.storage.AddLink(ctx, model, chatID)
 	if err != nil {
@@ -152,15 +273,13 @@ func (a *API) DeleteLinks(w http.ResponseWriter, r *http.Request, params DeleteL
 		return
 	}
 
-	u, err := url.Parse(model.Link)
+	link, err := sources.Canonical(model.Link)
 	if err != nil {
 		respondWithError(w, http.StatusBadRequest, ErrDeleteLinkInvalidLink.Error(), ErrInvalidBody.Error())
 		return
 	}
 
-	u.Scheme = config.SchemeSecure
-
-	model.Link = u.String()
+	model.Link = link
 
 	if err := a.storage.DeleteLink(ctx, model, chatID); err != nil {
 		var status int
@@ -0,0 +1,40 @@
+package scrapperapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	scrapperapi "github.com/es-debug/backend-academy-2024-go-template/internal/api/openapi/v1/servers/scrapper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPutTgChatIdTemplateRejectsInvalidTemplates(t *testing.T) {
+	tests := map[string]string{
+		"does not parse":     "{{.Title",
+		"unknown field":      "{{.Missing}}",
+		"runs without bound": "{{range 2000000000}}{{end}}x",
+		"malformed markup":   "<h1>{{.Title}}</h1>",
+		"too long":           strings.Repeat("x", 2049),
+	}
+
+	for name, template := range tests {
+		t.Run(name, func(t *testing.T) {
+			body, err := json.Marshal(scrapperapi.UpdateTemplateRequest{Template: template})
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPut, "/tg-chat/1/template", strings.NewReader(string(body)))
+			rec := httptest.NewRecorder()
+
+			scrapperapi.New(nil, nil).PutTgChatIdTemplate(rec, req, 1)
+
+			require.Equal(t, http.StatusBadRequest, rec.Code)
+
+			var resp scrapperapi.ApiErrorResponse
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+			require.Equal(t, scrapperapi.ErrInvalidTemplate.Error(), resp.Description)
+		})
+	}
+}
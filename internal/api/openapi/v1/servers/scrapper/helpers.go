@@ -2,8 +2,11 @@ package scrapperapi
 
 import (
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/sources"
 )
 
 func respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
@@ -29,13 +32,29 @@ func respondWithError(w http.ResponseWriter, code int, msg, description string)
 	respondWithJSON(w, code, err)
 }
 
-func isAvailable(url string) bool {
-	req, err := http.NewRequest(http.MethodGet, url, http.NoBody)
+func chatStatus(err error) int {
+	if errors.Is(err, ErrChatNotExists) {
+		return http.StatusNotFound
+	}
+
+	return http.StatusBadRequest
+}
+
+func (a *API) isAvailable(link string) bool {
+	req, err := http.NewRequest(http.MethodGet, link, http.NoBody)
 	if err != nil {
 		return false
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	client := http.DefaultClient
+
+	if source, _, err := sources.Lookup(link); err == nil {
+		if _, ok := source.(sources.Fallback); ok {
+			client = a.guarded
+		}
+	}
+
+	resp, err := client.Do(req)
 	if err != nil {
 		return false
 	}
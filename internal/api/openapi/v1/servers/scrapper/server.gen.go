@@ -12,11 +12,18 @@ import (
 	"github.com/oapi-codegen/runtime"
 )
 
+// Defines values for DeliveryMode.
+const (
+	Digest  DeliveryMode = "digest"
+	Instant DeliveryMode = "instant"
+)
+
 // AddLinkRequest defines model for AddLinkRequest.
 type AddLinkRequest struct {
-	Filters []string `json:"filters"`
-	Link    string   `json:"link"`
-	Tags    []string `json:"tags"`
+	Filters []string      `json:"filters"`
+	Link    string        `json:"link"`
+	Mode    *DeliveryMode `json:"mode,omitempty"`
+	Tags    []string      `json:"tags"`
 }
 
 // ApiErrorResponse defines model for ApiErrorResponse.
@@ -26,6 +33,15 @@ type ApiErrorResponse struct {
 	ErrorMessage string `json:"errorMessage"`
 }
 
+// DeliveryMode defines model for DeliveryMode.
+type DeliveryMode string
+
+// DigestResponse defines model for DigestResponse.
+type DigestResponse struct {
+	Schedule string `json:"schedule"`
+	Timezone string `json:"timezone"`
+}
+
 // LinkResponse defines model for LinkResponse.
 type LinkResponse struct {
 	Filters []string `json:"filters"`
@@ -45,6 +61,22 @@ type RemoveLinkRequest struct {
 	Link string `json:"link"`
 }
 
+// TemplateResponse defines model for TemplateResponse.
+type TemplateResponse struct {
+	Template string `json:"template"`
+}
+
+// UpdateDigestRequest defines model for UpdateDigestRequest.
+type UpdateDigestRequest struct {
+	Schedule *string `json:"schedule,omitempty"`
+	Timezone *string `json:"timezone,omitempty"`
+}
+
+// UpdateTemplateRequest defines model for UpdateTemplateRequest.
+type UpdateTemplateRequest struct {
+	Template string `json:"template"`
+}
+
 // DeleteLinksParams defines parameters for DeleteLinks.
 type DeleteLinksParams struct {
 	TgChatId int64 `json:"Tg-Chat-Id"`
@@ -66,6 +98,12 @@ type DeleteLinksJSONRequestBody = RemoveLinkRequest
 // PostLinksJSONRequestBody defines body for PostLinks for application/json ContentType.
 type PostLinksJSONRequestBody = AddLinkRequest
 
+// PutTgChatIdDigestJSONRequestBody defines body for PutTgChatIdDigest for application/json ContentType.
+type PutTgChatIdDigestJSONRequestBody = UpdateDigestRequest
+
+// PutTgChatIdTemplateJSONRequestBody defines body for PutTgChatIdTemplate for application/json ContentType.
+type PutTgChatIdTemplateJSONRequestBody = UpdateTemplateRequest
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// Убрать отслеживание ссылки
@@ -86,6 +124,18 @@ type ServerInterface interface {
 	// Зарегистрировать чат
 	// (POST /tg-chat/{id})
 	PostTgChatId(w http.ResponseWriter, r *http.Request, id int64)
+	// Получить расписание дайджеста чата
+	// (GET /tg-chat/{id}/digest)
+	GetTgChatIdDigest(w http.ResponseWriter, r *http.Request, id int64)
+	// Изменить расписание дайджеста чата
+	// (PUT /tg-chat/{id}/digest)
+	PutTgChatIdDigest(w http.ResponseWriter, r *http.Request, id int64)
+	// Получить шаблон уведомлений чата
+	// (GET /tg-chat/{id}/template)
+	GetTgChatIdTemplate(w http.ResponseWriter, r *http.Request, id int64)
+	// Изменить шаблон уведомлений чата
+	// (PUT /tg-chat/{id}/template)
+	PutTgChatIdTemplate(w http.ResponseWriter, r *http.Request, id int64)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -304,6 +354,106 @@ func (siw *ServerInterfaceWrapper) PostTgChatId(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
+// GetTgChatIdDigest operation middleware
+func (siw *ServerInterfaceWrapper) GetTgChatIdDigest(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTgChatIdDigest(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutTgChatIdDigest operation middleware
+func (siw *ServerInterfaceWrapper) PutTgChatIdDigest(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutTgChatIdDigest(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetTgChatIdTemplate operation middleware
+func (siw *ServerInterfaceWrapper) GetTgChatIdTemplate(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTgChatIdTemplate(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutTgChatIdTemplate operation middleware
+func (siw *ServerInterfaceWrapper) PutTgChatIdTemplate(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutTgChatIdTemplate(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 type UnescapedCookieParamError struct {
 	ParamName string
 	Err       error
@@ -430,6 +580,10 @@ func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.H
 	m.HandleFunc("DELETE "+options.BaseURL+"/tg-chat/{id}", wrapper.DeleteTgChatId)
 	m.HandleFunc("GET "+options.BaseURL+"/tg-chat/{id}", wrapper.GetTgChatId)
 	m.HandleFunc("POST "+options.BaseURL+"/tg-chat/{id}", wrapper.PostTgChatId)
+	m.HandleFunc("GET "+options.BaseURL+"/tg-chat/{id}/digest", wrapper.GetTgChatIdDigest)
+	m.HandleFunc("PUT "+options.BaseURL+"/tg-chat/{id}/digest", wrapper.PutTgChatIdDigest)
+	m.HandleFunc("GET "+options.BaseURL+"/tg-chat/{id}/template", wrapper.GetTgChatIdTemplate)
+	m.HandleFunc("PUT "+options.BaseURL+"/tg-chat/{id}/template", wrapper.PutTgChatIdTemplate)
 
 	return m
 }
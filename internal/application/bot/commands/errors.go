@@ -13,4 +13,7 @@ var (
 	ErrInvalidAck           = commandsError{msg: "your acknowledgment should be either yes or no"}
 	ErrLinkAlreadyExists    = commandsError{msg: "link is already being tracked"}
 	ErrLinkNotExists        = commandsError{msg: "link is not yet begin tracked"}
+	ErrInvalidSchedule      = commandsError{msg: "schedule is empty"}
+	ErrInvalidTimezone      = commandsError{msg: "timezone is unknown"}
+	ErrInvalidTemplate      = commandsError{msg: "template does not render a sample update"}
 )
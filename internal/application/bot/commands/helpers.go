@@ -6,25 +6,38 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"net/url"
 	"slices"
 	"strings"
+	"time"
 
-	"github.com/es-debug/backend-academy-2024-go-template/config"
 	sclient "github.com/es-debug/backend-academy-2024-go-template/internal/api/openapi/v1/clients/scrapper"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/filter"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/render"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/sources"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
 )
 
 const (
 	FiltersAck = "❔ Do you want to specify filters? (press /cancel to quit)"
 	TagsAck    = "❔ Do you want to specify tags? (press /cancel to quit)"
+	ModeAck    = "❔ Do you want to get updates instantly? Otherwise they arrive with the scheduled digest. " +
+		"(press /cancel to quit)"
 
 	TagsRequest    = "✨ Please, enter link tags separated by space. (press /cancel to quit)"
-	FiltersRequest = "✨ Please, enter link filters as filter:value. (press /cancel to quit)"
+	FiltersRequest = "✨ Please, enter link filters as filter:value, prefix with '-' to exclude. " +
+		"Supported filters: user, type, label, keyword, release, score, unanswered. (press /cancel to quit)"
 
 	LinkManual    = "💥 Invalid URL! Please enter a valid link (e.g. https://github.com/golang/go)"
 	AcksManual    = "💥 Only yes/no are acceptable!"
 	TagsManual    = "💥 Invalid tags! Use spaces to separate (e.g. 'work hobby')."
-	FiltersManual = "💥 Invalid format! Use 'filter:value' (e.g. 'user:dummy')."
+	FiltersManual = "💥 Invalid filters! Use 'filter:value' (e.g. 'user:dummy' or '-user:dependabot') with " +
+		"user, type, label, keyword, release (major, minor, patch, prerelease), " +
+		"score (a number, optionally after >=, <=, >, < or =) or unanswered (true, false)."
+
+	ScheduleManual = "💥 Invalid schedule! Use times like '09:00,18:30' or a cron expression like '0 9 * * 1-5'."
+	TimezoneManual = "💥 Unknown timezone! Use an IANA name (e.g. 'Europe/Moscow' or 'America/New_York')."
+	TemplateManual = "💥 Invalid template! Check the field names, close every tag, write <, > and & " +
+		"in text as &lt;, &gt; and &amp;, and range only over .Labels, without nesting."
 )
 
 type Client interface {
@@ -34,6 +47,10 @@ type Client interface {
 		reqEditors ...sclient.RequestEditorFn) (*http.Response, error)
 	GetLinks(ctx context.Context, params *sclient.GetLinksParams,
 		reqEditors ...sclient.RequestEditorFn) (*http.Response, error)
+	PutTgChatIdDigest(ctx context.Context, id int64, body sclient.PutTgChatIdDigestJSONRequestBody,
+		reqEditors ...sclient.RequestEditorFn) (*http.Response, error)
+	PutTgChatIdTemplate(ctx context.Context, id int64, body sclient.PutTgChatIdTemplateJSONRequestBody,
+		reqEditors ...sclient.RequestEditorFn) (*http.Response, error)
 }
 
 type Cache interface {
@@ -71,8 +88,8 @@ func MatchFilters(got, desired []string) bool {
 		return false
 	}
 
-	for _, filter := range desired {
-		if !slices.Contains(got, filter) {
+	for _, item := range desired {
+		if !slices.Contains(got, item) {
 			return false
 		}
 	}
@@ -81,13 +98,7 @@ func MatchFilters(got, desired []string) bool {
 }
 
 func ValidateLink(link string) error {
-	_, err := url.Parse(link)
-	if err != nil {
-		return ErrInvalidLinkFormat
-	}
-
-	if !((strings.Contains(link, config.StackOverflow) && strings.Contains(link, "questions")) ||
-		strings.Contains(link, config.GitHub)) {
+	if err := sources.Validate(link); err != nil {
 		return ErrInvalidLinkFormat
 	}
 
@@ -111,14 +122,44 @@ func ValidateTags(input string) error {
 	return nil
 }
 
+// ValidateFilters applies the rules the scrapper checks filters with,
+// so a filter it would reject is reported with the manual right away.
 func ValidateFilters(input string) error {
-	filters := strings.Fields(input)
+	if err := filter.Validate(strings.Fields(input)); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidFiltersFormat, err)
+	}
 
-	for _, filter := range filters {
-		pair := strings.Split(filter, ":")
-		if len(pair) != 2 {
-			return ErrInvalidFiltersFormat
-		}
+	return nil
+}
+
+func ValidateSchedule(input string) error {
+	if strings.TrimSpace(input) == "" {
+		return ErrInvalidSchedule
+	}
+
+	return nil
+}
+
+func ValidateTimezone(input string) error {
+	timezone := strings.TrimSpace(input)
+
+	if _, err := time.LoadLocation(timezone); err != nil || timezone == "" || timezone == "Local" {
+		return ErrInvalidTimezone
+	}
+
+	return nil
+}
+
+// ValidateTemplate accepts the word resetting the layout or a template that renders a sample update.
+func ValidateTemplate(input string) error {
+	text := strings.TrimSpace(input)
+
+	if strings.EqualFold(text, models.ResetTemplate) {
+		return nil
+	}
+
+	if _, err := render.ParseTemplate(text); err != nil {
+		return ErrInvalidTemplate
 	}
 
 	return nil
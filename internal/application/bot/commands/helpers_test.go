@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/es-debug/backend-academy-2024-go-template/internal/application/bot/commands"
+	_ "github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/sources/all"
 	"github.com/stretchr/testify/require"
 )
 
@@ -20,6 +21,14 @@ func TestValidateLink(t *testing.T) {
 			link:    "https://stackoverflow.com/questions/12345",
 			wantErr: false,
 		},
+		"valid gitlab": {
+			link:    "https://gitlab.com/group/project",
+			wantErr: false,
+		},
+		"github without repository": {
+			link:    "https://github.com/user",
+			wantErr: true,
+		},
 		"invalid http": {
 			link:    "http://example.com",
 			wantErr: true,
@@ -166,17 +175,37 @@ func TestValidateFilters(t *testing.T) {
 		wantErr bool
 	}{
 		"valid": {
-			input:   "user:admin repo:go",
+			input:   "user:admin label:go",
 			wantErr: false,
 		},
 		"missing semicolon": {
-			input:   "useradmin repo:go",
+			input:   "useradmin label:go",
 			wantErr: true,
 		},
 		"single bad format": {
 			input:   "badformat",
 			wantErr: true,
 		},
+		"negated": {
+			input:   "-user:dependabot type:pr",
+			wantErr: false,
+		},
+		"missing value": {
+			input:   "user:",
+			wantErr: true,
+		},
+		"unknown key": {
+			input:   "user:admin repo:go",
+			wantErr: true,
+		},
+		"invalid score": {
+			input:   "score:high",
+			wantErr: true,
+		},
+		"unknown release level": {
+			input:   "release:huge",
+			wantErr: true,
+		},
 	}
 
 	for name, test := range tests {
@@ -190,3 +219,73 @@ func TestValidateFilters(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateSchedule(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		wantErr bool
+	}{
+		"delivery times": {
+			input:   "09:00,18:30",
+			wantErr: false,
+		},
+		"cron expression": {
+			input:   "0 9 * * 1-5",
+			wantErr: false,
+		},
+		"blank": {
+			input:   "  ",
+			wantErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := commands.ValidateSchedule(test.input)
+			if test.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateTimezone(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		wantErr bool
+	}{
+		"iana name": {
+			input:   "Europe/Moscow",
+			wantErr: false,
+		},
+		"utc": {
+			input:   "UTC",
+			wantErr: false,
+		},
+		"unknown": {
+			input:   "Mars/Olympus",
+			wantErr: true,
+		},
+		"server local": {
+			input:   "Local",
+			wantErr: true,
+		},
+		"empty": {
+			input:   "",
+			wantErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := commands.ValidateTimezone(test.input)
+			if test.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
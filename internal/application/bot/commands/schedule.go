@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	sclient "github.com/es-debug/backend-academy-2024-go-template/internal/api/openapi/v1/clients/scrapper"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+)
+
+type Schedule struct {
+	Traits   *models.Traits
+	Pipeline []*models.Stage
+	Digest   sclient.UpdateDigestRequest
+	Client   Client
+}
+
+func NewSchedule(chatID int64, client Client) *Schedule {
+	return &Schedule{
+		Traits:   models.NewTraits(ScheduleSpan, chatID, CommandSchedule),
+		Pipeline: createScheduleStages(),
+		Client:   client,
+	}
+}
+
+func (c *Schedule) Validate(input string) error {
+	if err := c.Pipeline[c.Traits.Stage].Validate(input); err != nil {
+		c.Traits.Malformed = true
+		return err
+	}
+
+	c.Traits.HandleSchedule(input, &c.Digest)
+
+	return nil
+}
+
+func (c *Schedule) Stage() (string, bool) {
+	if !c.Traits.Malformed {
+		return c.Pipeline[c.Traits.Stage].Prompt, false
+	}
+
+	return c.Pipeline[c.Traits.Stage].Manual, false
+}
+
+func (c *Schedule) Done() bool {
+	return c.Traits.Stage == c.Traits.Span
+}
+
+func (c *Schedule) Request(ctx context.Context) (string, error) {
+	resp, err := c.Client.PutTgChatIdDigest(ctx, c.Traits.ChatID, c.Digest)
+	if err != nil {
+		return FailedSchedule, fmt.Errorf("command schedule: failed to put digest: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusBadRequest {
+		return ScheduleManual, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return FailedSchedule, fmt.Errorf("command schedule: client response code: %d", resp.StatusCode)
+	}
+
+	return SuccessfulSchedule, nil
+}
+
+func (c *Schedule) Name() string {
+	return c.Traits.Name
+}
+
+func createScheduleStages() []*models.Stage {
+	return []*models.Stage{
+		models.NewStage(ScheduleRequest, ScheduleManual, ValidateSchedule),
+	}
+}
+
+const (
+	CommandSchedule = "schedule"
+	ScheduleSpan    = 1
+	ScheduleRequest = "✨ Please, enter digest times separated by comma (e.g. '09:00,18:30') or a cron expression " +
+		"(e.g. '0 9 * * 1-5'). (press /cancel to quit)"
+	FailedSchedule     = "💥 Failed to update digest schedule!"
+	SuccessfulSchedule = "✨ Digest schedule is updated!"
+)
@@ -0,0 +1,66 @@
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/bot/commands"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleRequest(t *testing.T) {
+	tests := map[string]struct {
+		status      int
+		expectedMsg string
+		wantErr     bool
+	}{
+		"successful update": {
+			status:      http.StatusOK,
+			expectedMsg: commands.SuccessfulSchedule,
+		},
+		"rejected schedule": {
+			status:      http.StatusBadRequest,
+			expectedMsg: commands.ScheduleManual,
+		},
+		"unregistered chat": {
+			status:      http.StatusNotFound,
+			expectedMsg: commands.FailedSchedule,
+			wantErr:     true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			client := mocks.NewMockScrapperClient(t)
+			defer client.AssertExpectations(t)
+
+			client.On("PutTgChatIdDigest", mock.Anything, int64(1), mock.Anything).
+				Once().Return(&http.Response{
+				StatusCode: test.status,
+				Body:       io.NopCloser(bytes.NewReader(nil)),
+			}, nil)
+
+			cmd := commands.NewSchedule(1, client)
+			require.NoError(t, cmd.Validate("09:00,18:30"))
+			require.True(t, cmd.Done())
+
+			actualMsg, err := cmd.Request(ctx)
+			if test.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, test.expectedMsg, actualMsg)
+			require.Equal(t, "09:00,18:30", *cmd.Digest.Schedule)
+			require.Nil(t, cmd.Digest.Timezone)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	sclient "github.com/es-debug/backend-academy-2024-go-template/internal/api/openapi/v1/clients/scrapper"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/render"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+)
+
+type Template struct {
+	Traits   *models.Traits
+	Pipeline []*models.Stage
+	Template sclient.UpdateTemplateRequest
+	Client   Client
+	preview  string
+}
+
+func NewTemplate(chatID int64, client Client) *Template {
+	return &Template{
+		Traits:   models.NewTraits(TemplateSpan, chatID, CommandTemplate),
+		Pipeline: createTemplateStages(),
+		Client:   client,
+	}
+}
+
+func (c *Template) Validate(input string) error {
+	if err := c.Pipeline[c.Traits.Stage].Validate(input); err != nil {
+		c.Traits.Malformed = true
+		return err
+	}
+
+	c.Traits.HandleTemplate(input, &c.Template)
+
+	return nil
+}
+
+func (c *Template) Stage() (string, bool) {
+	if !c.Traits.Malformed {
+		return c.Pipeline[c.Traits.Stage].Prompt, false
+	}
+
+	return c.Pipeline[c.Traits.Stage].Manual, false
+}
+
+func (c *Template) Done() bool {
+	return c.Traits.Stage == c.Traits.Span
+}
+
+// Request saves the template and replies with the sample update rendered by it.
+func (c *Template) Request(ctx context.Context) (string, error) {
+	resp, err := c.Client.PutTgChatIdTemplate(ctx, c.Traits.ChatID, c.Template)
+	if err != nil {
+		return FailedTemplate, fmt.Errorf("command template: failed to put template: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusBadRequest {
+		return TemplateManual, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return FailedTemplate, fmt.Errorf("command template: client response code: %d", resp.StatusCode)
+	}
+
+	if c.Template.Template == "" {
+		return SuccessfulTemplateReset, nil
+	}
+
+	preview, err := render.Preview(c.Template.Template)
+	if err != nil {
+		return SuccessfulTemplate, fmt.Errorf("command template: failed to render preview: %w", err)
+	}
+
+	c.preview = preview
+
+	return SuccessfulTemplatePreview + "\n\n" + preview, nil
+}
+
+// HTML reports whether the reply carries a preview, which is Telegram HTML.
+func (c *Template) HTML() bool {
+	return c.preview != ""
+}
+
+func (c *Template) Name() string {
+	return c.Traits.Name
+}
+
+func createTemplateStages() []*models.Stage {
+	return []*models.Stage{
+		models.NewStage(TemplateRequest, TemplateManual, ValidateTemplate),
+	}
+}
+
+const (
+	CommandTemplate = "template"
+	TemplateSpan    = 1
+	TemplateRequest = "✨ Please, enter a notification template in Go text/template syntax " +
+		"(e.g. '<b>{{.Title}}</b> by {{.Author}} {{.URL}}'), or 'default' for the standard layout. " +
+		"It lays out instant updates and every update listed in a digest. " +
+		"Fields: Link, Source, Kind, Title, URL, Author, Labels, Body, Date, CreatedAt, UpdatedAt. " +
+		"Functions: join, truncate, date, printf. Tags: b, i, u, s, code, pre, a. (press /cancel to quit)"
+	FailedTemplate            = "💥 Failed to update template!"
+	SuccessfulTemplate        = "✨ Template is saved!"
+	SuccessfulTemplatePreview = "✨ Template is saved! This is how updates will look, alone and in digests:"
+	SuccessfulTemplateReset   = "✨ Updates will use the default layout again!"
+)
@@ -0,0 +1,98 @@
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/bot/commands"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateRequest(t *testing.T) {
+	tests := map[string]struct {
+		input       string
+		status      int
+		expectedMsg string
+		html        bool
+		wantErr     bool
+	}{
+		"saved template": {
+			input:       "{{.Kind}}: <b>{{.Title}}</b>",
+			status:      http.StatusOK,
+			expectedMsg: commands.SuccessfulTemplatePreview + "\n\nissue: <b>Bot crashes on start without a config file</b>",
+			html:        true,
+		},
+		"reset template": {
+			input:       "default",
+			status:      http.StatusOK,
+			expectedMsg: commands.SuccessfulTemplateReset,
+		},
+		"rejected template": {
+			input:       "{{.Title}}",
+			status:      http.StatusBadRequest,
+			expectedMsg: commands.TemplateManual,
+		},
+		"server failure": {
+			input:       "{{.Title}}",
+			status:      http.StatusInternalServerError,
+			expectedMsg: commands.FailedTemplate,
+			wantErr:     true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			client := mocks.NewMockScrapperClient(t)
+			defer client.AssertExpectations(t)
+
+			client.On("PutTgChatIdTemplate", mock.Anything, int64(1), mock.Anything).
+				Once().Return(&http.Response{
+				StatusCode: test.status,
+				Body:       io.NopCloser(bytes.NewReader(nil)),
+			}, nil)
+
+			cmd := commands.NewTemplate(1, client)
+			require.NoError(t, cmd.Validate(test.input))
+			require.True(t, cmd.Done())
+
+			actualMsg, err := cmd.Request(ctx)
+			if test.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, test.expectedMsg, actualMsg)
+			require.Equal(t, test.html, cmd.HTML())
+		})
+	}
+}
+
+func TestTemplateValidateRejectsBroken(t *testing.T) {
+	tests := map[string]string{
+		"unknown field":  "{{.Missing}}",
+		"syntax error":   "{{.Title",
+		"unclosed tag":   "<b>{{.Title}}",
+		"unsupported":    "<h1>{{.Title}}</h1>",
+		"bare ampersand": "{{.Title}} & more",
+	}
+
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			cmd := commands.NewTemplate(1, mocks.NewMockScrapperClient(t))
+
+			require.ErrorIs(t, cmd.Validate(input), commands.ErrInvalidTemplate)
+
+			prompt, _ := cmd.Stage()
+			require.Equal(t, commands.TemplateManual, prompt)
+			require.False(t, cmd.Done())
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	sclient "github.com/es-debug/backend-academy-2024-go-template/internal/api/openapi/v1/clients/scrapper"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+)
+
+type Timezone struct {
+	Traits   *models.Traits
+	Pipeline []*models.Stage
+	Digest   sclient.UpdateDigestRequest
+	Client   Client
+}
+
+func NewTimezone(chatID int64, client Client) *Timezone {
+	return &Timezone{
+		Traits:   models.NewTraits(TimezoneSpan, chatID, CommandTimezone),
+		Pipeline: createTimezoneStages(),
+		Client:   client,
+	}
+}
+
+func (c *Timezone) Validate(input string) error {
+	if err := c.Pipeline[c.Traits.Stage].Validate(input); err != nil {
+		c.Traits.Malformed = true
+		return err
+	}
+
+	c.Traits.HandleTimezone(input, &c.Digest)
+
+	return nil
+}
+
+func (c *Timezone) Stage() (string, bool) {
+	if !c.Traits.Malformed {
+		return c.Pipeline[c.Traits.Stage].Prompt, false
+	}
+
+	return c.Pipeline[c.Traits.Stage].Manual, false
+}
+
+func (c *Timezone) Done() bool {
+	return c.Traits.Stage == c.Traits.Span
+}
+
+func (c *Timezone) Request(ctx context.Context) (string, error) {
+	resp, err := c.Client.PutTgChatIdDigest(ctx, c.Traits.ChatID, c.Digest)
+	if err != nil {
+		return FailedTimezone, fmt.Errorf("command timezone: failed to put digest: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusBadRequest {
+		return TimezoneManual, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return FailedTimezone, fmt.Errorf("command timezone: client response code: %d", resp.StatusCode)
+	}
+
+	return SuccessfulTimezone, nil
+}
+
+func (c *Timezone) Name() string {
+	return c.Traits.Name
+}
+
+func createTimezoneStages() []*models.Stage {
+	return []*models.Stage{
+		models.NewStage(TimezoneRequest, TimezoneManual, ValidateTimezone),
+	}
+}
+
+const (
+	CommandTimezone    = "timezone"
+	TimezoneSpan       = 1
+	TimezoneRequest    = "✨ Please, enter your timezone (e.g. 'Europe/Moscow' or 'UTC'). (press /cancel to quit)"
+	FailedTimezone     = "💥 Failed to update timezone!"
+	SuccessfulTimezone = "✨ Digests will now follow your timezone!"
+)
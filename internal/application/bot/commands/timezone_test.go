@@ -0,0 +1,76 @@
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/bot/commands"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimezoneRequest(t *testing.T) {
+	tests := map[string]struct {
+		status      int
+		expectedMsg string
+		wantErr     bool
+	}{
+		"successful update": {
+			status:      http.StatusOK,
+			expectedMsg: commands.SuccessfulTimezone,
+		},
+		"rejected timezone": {
+			status:      http.StatusBadRequest,
+			expectedMsg: commands.TimezoneManual,
+		},
+		"server failure": {
+			status:      http.StatusInternalServerError,
+			expectedMsg: commands.FailedTimezone,
+			wantErr:     true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			client := mocks.NewMockScrapperClient(t)
+			defer client.AssertExpectations(t)
+
+			client.On("PutTgChatIdDigest", mock.Anything, int64(1), mock.Anything).
+				Once().Return(&http.Response{
+				StatusCode: test.status,
+				Body:       io.NopCloser(bytes.NewReader(nil)),
+			}, nil)
+
+			cmd := commands.NewTimezone(1, client)
+			require.NoError(t, cmd.Validate("America/New_York"))
+			require.True(t, cmd.Done())
+
+			actualMsg, err := cmd.Request(ctx)
+			if test.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, test.expectedMsg, actualMsg)
+			require.Equal(t, "America/New_York", *cmd.Digest.Timezone)
+			require.Nil(t, cmd.Digest.Schedule)
+		})
+	}
+}
+
+func TestTimezoneValidateRejectsUnknown(t *testing.T) {
+	cmd := commands.NewTimezone(1, mocks.NewMockScrapperClient(t))
+
+	require.ErrorIs(t, cmd.Validate("Mars/Olympus"), commands.ErrInvalidTimezone)
+
+	prompt, _ := cmd.Stage()
+	require.Equal(t, commands.TimezoneManual, prompt)
+	require.False(t, cmd.Done())
+}
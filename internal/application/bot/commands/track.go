@@ -40,7 +40,7 @@ func (c *Track) Validate(input string) error {
 }
 
 func (c *Track) Stage() (string, bool) {
-	keyboard := c.Traits.Stage == 1 || c.Traits.Stage == 3
+	keyboard := c.Traits.Stage == 1 || c.Traits.Stage == 3 || c.Traits.Stage == 5
 
 	if !c.Traits.Malformed {
 		return c.Pipeline[c.Traits.Stage].Prompt, keyboard
@@ -62,6 +62,7 @@ func (c *Track) Request(ctx context.Context) (string, error) {
 		Link:    c.Link.Link,
 		Tags:    c.Link.Tags,
 		Filters: c.Link.Filters,
+		Mode:    c.Link.Mode,
 	}
 
 	resp, err := c.Client.PostLinks(ctx, params, body)
@@ -101,12 +102,13 @@ func createTrackStages() []*models.Stage {
 		models.NewStage(TagsRequest, TagsManual, ValidateTags),
 		models.NewStage(FiltersAck, AcksManual, ValidateAck),
 		models.NewStage(FiltersRequest, FiltersManual, ValidateFilters),
+		models.NewStage(ModeAck, AcksManual, ValidateAck),
 	}
 }
 
 const (
 	CommandTrack       = "track"
-	TrackSpan          = 5
+	TrackSpan          = 6
 	TrackRequest       = "✨ Please, enter the link you want to track! (press /cancel to quit)"
 	FailedTrack        = "💥 Failed to track link!"
 	LinkAlreadyTracked = "⚡️ This link is already being tracked!"
@@ -15,11 +15,14 @@ import (
 	"github.com/es-debug/backend-academy-2024-go-template/internal/application/bot/processor"
 	botservice "github.com/es-debug/backend-academy-2024-go-template/internal/application/bot/service"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/application/bot/telebot"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/bot/templates"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/sources"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/consumers"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/producers"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/repository/list"
 	botserver "github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/servers/bot"
+	_ "github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/sources/all"
 	cbt "github.com/es-debug/backend-academy-2024-go-template/pkg/cbtransport"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/redis/go-redis/v9"
@@ -54,6 +57,10 @@ func Config() (*config.Config, error) {
 		return nil, err
 	}
 
+	if err := sources.Configure(cfg); err != nil {
+		return nil, err
+	}
+
 	return cfg, nil
 }
 
@@ -107,8 +114,9 @@ func BotServer(
 	tgc *tgbotapi.BotAPI,
 	cfg *config.Config,
 	lmt *limiter.Limiter,
+	store *templates.Store,
 ) *http.Server {
-	api := botapi.New(tgc)
+	api := botapi.New(tgc, store)
 	return botserver.New(cfg, api, lmt)
 }
 
@@ -116,6 +124,10 @@ func Telebot(client sclient.ClientInterface, tgc *tgbotapi.BotAPI, cache *list.C
 	return telebot.New(client, tgc, cache)
 }
 
+func Templates(client sclient.ClientInterface) *templates.Store {
+	return templates.New(client)
+}
+
 func Deserializer() *models.Deserializer {
 	return models.NewDeserializer()
 }
@@ -187,8 +199,9 @@ func Processor(
 	telegramSender *tgbotapi.BotAPI,
 	deserializer *models.Deserializer,
 	dlqPublisher *producers.DLQPublisher,
+	store *templates.Store,
 ) *processor.Processor {
-	return processor.New(telegramSender, deserializer, dlqPublisher)
+	return processor.New(telegramSender, deserializer, dlqPublisher, store)
 }
 
 func BotService(
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/render"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/segmentio/kafka-go"
@@ -23,21 +24,28 @@ type DLQSender interface {
 	Send(ctx context.Context, msg kafka.Message, reason string) error
 }
 
+type Templates interface {
+	ForChat(ctx context.Context, chatID int64) string
+}
+
 type Processor struct {
 	telegramSender TelegramSender
 	deserializer   Deserializer
 	dlqSender      DLQSender
+	templates      Templates
 }
 
 func New(
 	telegramSender TelegramSender,
 	deserializer Deserializer,
 	dlqSender DLQSender,
+	templates Templates,
 ) *Processor {
 	return &Processor{
 		telegramSender: telegramSender,
 		deserializer:   deserializer,
 		dlqSender:      dlqSender,
+		templates:      templates,
 	}
 }
 
@@ -51,17 +59,35 @@ func (p *Processor) Process(ctx context.Context, msg kafka.Message) error {
 		return fmt.Errorf("processor: failed to deserialize update: %w", err)
 	}
 
-	if _, err := p.telegramSender.Send(tgbotapi.NewMessage(
-		update.ChatID, fmt.Sprintf(`✨ New update via %s!
-
-%s`, update.Url, update.Description),
-	)); err != nil {
-		if err = p.dlqSender.Send(ctx, msg,
-			fmt.Sprintf("telegram send failed: %s", err.Error())); err != nil {
-			return fmt.Errorf("processor: %w", err)
+	for _, text := range p.messages(ctx, update) {
+		if _, err := p.telegramSender.Send(message(update.ChatID, text)); err != nil {
+			if err = p.dlqSender.Send(ctx, msg,
+				fmt.Sprintf("telegram send failed: %s", err.Error())); err != nil {
+				return fmt.Errorf("processor: %w", err)
+			}
+			return fmt.Errorf("processor: failed to send telegram message: %w", err)
 		}
-		return fmt.Errorf("processor: failed to send telegram message: %w", err)
 	}
 
 	return nil
 }
+
+// messages turns a digest into its grouped messages and a single update into its own,
+// both laid out by the chat's template.
+func (p *Processor) messages(ctx context.Context, update models.KafkaUpdate) []string {
+	template := p.templates.ForChat(ctx, update.ChatID)
+
+	if update.Digest != nil {
+		return render.DigestWithTemplate(template, *update.Digest)
+	}
+
+	return render.WithTemplate(template, update.Url, update.Update)
+}
+
+func message(chatID int64, text string) tgbotapi.MessageConfig {
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = tgbotapi.ModeHTML
+	msg.DisableWebPagePreview = true
+
+	return msg
+}
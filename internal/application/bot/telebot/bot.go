@@ -26,6 +26,10 @@ type ScrapperClient interface {
 	DeleteTgChatId(ctx context.Context, id int64, reqEditors ...sclient.RequestEditorFn) (*http.Response, error)
 	GetTgChatId(ctx context.Context, id int64, reqEditors ...sclient.RequestEditorFn) (*http.Response, error)
 	PostTgChatId(ctx context.Context, id int64, reqEditors ...sclient.RequestEditorFn) (*http.Response, error)
+	PutTgChatIdDigest(ctx context.Context, id int64, body sclient.PutTgChatIdDigestJSONRequestBody,
+		reqEditors ...sclient.RequestEditorFn) (*http.Response, error)
+	PutTgChatIdTemplate(ctx context.Context, id int64, body sclient.PutTgChatIdTemplateJSONRequestBody,
+		reqEditors ...sclient.RequestEditorFn) (*http.Response, error)
 }
 
 type Command interface {
@@ -36,6 +40,11 @@ type Command interface {
 	Name() string
 }
 
+// Formatted is implemented by commands whose reply may be Telegram HTML.
+type Formatted interface {
+	HTML() bool
+}
+
 type Cache interface {
 	Add(ctx context.Context, chatID int64, link sclient.LinkResponse) error
 	Delete(ctx context.Context, chatID int64, req sclient.RemoveLinkRequest) error
@@ -98,10 +107,13 @@ func (b *Bot) Run(ctx context.Context) error {
 }
 
 const (
-	Start   = "start"
-	Help    = "help"
-	Cancel  = "cancel"
-	Track   = "track"
-	Untrack = "untrack"
-	List    = "list"
+	Start    = "start"
+	Help     = "help"
+	Cancel   = "cancel"
+	Track    = "track"
+	Untrack  = "untrack"
+	List     = "list"
+	Schedule = "schedule"
+	Timezone = "timezone"
+	Template = "template"
 )
@@ -21,7 +21,14 @@ func (b *Bot) CommandRequest(ctx context.Context, msg *tgbotapi.Message) tgbotap
 		)
 	}
 
-	return tgbotapi.NewMessage(msg.Chat.ID, reply)
+	message := tgbotapi.NewMessage(msg.Chat.ID, reply)
+
+	if formatted, ok := currentCommand.(Formatted); ok && formatted.HTML() {
+		message.ParseMode = tgbotapi.ModeHTML
+		message.DisableWebPagePreview = true
+	}
+
+	return message
 }
 
 func (b *Bot) QueryHandler(ctx context.Context, query *tgbotapi.CallbackQuery) tgbotapi.MessageConfig {
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/bot/commands"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/application/bot/telebot"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/mocks"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
@@ -39,6 +40,31 @@ func TestCommandRequest(t *testing.T) {
 	require.Equal(t, expectedMsg, actualMsg)
 }
 
+func TestCommandRequestPreview(t *testing.T) {
+	ctx := context.Background()
+
+	var chatID int64 = 1
+
+	client := mocks.NewMockScrapperClient(t)
+	client.On("PutTgChatIdTemplate", mock.Anything, chatID, mock.Anything).
+		Once().Return(&http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewReader(nil)),
+	}, nil)
+
+	cmd := commands.NewTemplate(chatID, client)
+	require.NoError(t, cmd.Validate("<b>{{.Title}}</b>"))
+
+	b := &telebot.Bot{CommandStates: xsync.NewMap[int64, telebot.Command]()}
+	b.CommandStates.Store(chatID, cmd)
+
+	actualMsg := b.CommandRequest(ctx, &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: chatID}})
+
+	require.Equal(t, tgbotapi.ModeHTML, actualMsg.ParseMode)
+	require.Equal(t, commands.SuccessfulTemplatePreview+"\n\n<b>Bot crashes on start without a config file</b>",
+		actualMsg.Text)
+}
+
 func TestQueryHandler(t *testing.T) {
 	var (
 		chatID int64 = 1
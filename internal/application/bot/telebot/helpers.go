@@ -74,6 +74,12 @@ func (b *Bot) InitializeCommand(msg *tgbotapi.Message) tgbotapi.MessageConfig {
 		b.CommandStates.Store(msg.Chat.ID, commands.NewUntrack(msg.Chat.ID, b.Client, b.Cache))
 	case List:
 		b.CommandStates.Store(msg.Chat.ID, commands.NewList(msg.Chat.ID, b.Client, b.Cache))
+	case Schedule:
+		b.CommandStates.Store(msg.Chat.ID, commands.NewSchedule(msg.Chat.ID, b.Client))
+	case Timezone:
+		b.CommandStates.Store(msg.Chat.ID, commands.NewTimezone(msg.Chat.ID, b.Client))
+	case Template:
+		b.CommandStates.Store(msg.Chat.ID, commands.NewTemplate(msg.Chat.ID, b.Client))
 	default:
 		return tgbotapi.NewMessage(msg.Chat.ID, UnknownCommand)
 	}
@@ -52,6 +52,34 @@ func TestInitializeCommand(t *testing.T) {
 			},
 			expectedReply: commands.TrackRequest,
 		},
+		"schedule command": {
+			msg: &tgbotapi.Message{
+				Chat: &tgbotapi.Chat{ID: chatID},
+				Text: "/schedule",
+				Entities: []tgbotapi.MessageEntity{
+					{
+						Type:   "bot_command",
+						Offset: 0,
+						Length: len("/schedule"),
+					},
+				},
+			},
+			expectedReply: commands.ScheduleRequest,
+		},
+		"template command": {
+			msg: &tgbotapi.Message{
+				Chat: &tgbotapi.Chat{ID: chatID},
+				Text: "/template",
+				Entities: []tgbotapi.MessageEntity{
+					{
+						Type:   "bot_command",
+						Offset: 0,
+						Length: len("/template"),
+					},
+				},
+			},
+			expectedReply: commands.TemplateRequest,
+		},
 	}
 
 	for name, test := range tests {
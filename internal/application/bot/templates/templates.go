@@ -0,0 +1,83 @@
+// Package templates reads the notification templates chats keep in the scrapper.
+package templates
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	sclient "github.com/es-debug/backend-academy-2024-go-template/internal/api/openapi/v1/clients/scrapper"
+	"github.com/es-debug/backend-academy-2024-go-template/pkg/lru"
+)
+
+const (
+	// cacheTTL bounds how long a changed template takes to reach deliveries,
+	// while a burst of updates for one chat asks the scrapper once.
+	cacheTTL  = 30 * time.Second
+	cacheSize = 1024
+)
+
+type Client interface {
+	GetTgChatIdTemplate(ctx context.Context, id int64,
+		reqEditors ...sclient.RequestEditorFn) (*http.Response, error)
+}
+
+type Store struct {
+	Client Client
+	cache  *lru.Cache[int64, cached]
+}
+
+type cached struct {
+	template string
+	expires  time.Time
+}
+
+func New(client Client) *Store {
+	return &Store{
+		Client: client,
+		cache:  lru.New[int64, cached](cacheSize),
+	}
+}
+
+// ForChat returns the template deliveries to a chat are laid out with. An unreachable
+// scrapper costs the chat its layout, not the delivery, so errors fall back to the default.
+func (s *Store) ForChat(ctx context.Context, chatID int64) string {
+	template, err := s.Get(ctx, chatID)
+	if err != nil {
+		slog.Warn("templates: failed to get chat template",
+			slog.Int64("chat_id", chatID),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return template
+}
+
+// Get returns the template of a chat, empty when the chat uses the default layout.
+func (s *Store) Get(ctx context.Context, chatID int64) (string, error) {
+	if entry, ok := s.cache.Get(chatID); ok && time.Now().Before(entry.expires) {
+		return entry.template, nil
+	}
+
+	resp, err := s.Client.GetTgChatIdTemplate(ctx, chatID)
+	if err != nil {
+		return "", fmt.Errorf("templates: failed to get template: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("templates: client response code: %d", resp.StatusCode)
+	}
+
+	var body sclient.TemplateResponse
+	if err = json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("templates: failed to decode template response: %w", err)
+	}
+
+	s.cache.Put(chatID, cached{template: body.Template, expires: time.Now().Add(cacheTTL)})
+
+	return body.Template, nil
+}
@@ -0,0 +1,86 @@
+package templates_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/bot/templates"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreGet(t *testing.T) {
+	tests := map[string]struct {
+		status   int
+		body     string
+		expected string
+		wantErr  bool
+	}{
+		"custom template": {
+			status:   http.StatusOK,
+			body:     `{"template":"{{.Title}}"}`,
+			expected: "{{.Title}}",
+		},
+		"default layout": {
+			status: http.StatusOK,
+			body:   `{"template":""}`,
+		},
+		"unknown chat": {
+			status:  http.StatusNotFound,
+			body:    `{}`,
+			wantErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := mocks.NewMockScrapperClient(t)
+			client.On("GetTgChatIdTemplate", mock.Anything, int64(1)).
+				Once().Return(&http.Response{
+				StatusCode: test.status,
+				Body:       io.NopCloser(bytes.NewReader([]byte(test.body))),
+			}, nil)
+
+			template, err := templates.New(client).Get(context.Background(), 1)
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.expected, template)
+		})
+	}
+}
+
+func TestStoreCachesTemplates(t *testing.T) {
+	client := mocks.NewMockScrapperClient(t)
+	client.On("GetTgChatIdTemplate", mock.Anything, int64(1)).
+		Once().Return(&http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewReader([]byte(`{"template":"{{.Title}}"}`))),
+	}, nil)
+
+	store := templates.New(client)
+
+	for range 3 {
+		require.Equal(t, "{{.Title}}", store.ForChat(context.Background(), 1))
+	}
+}
+
+func TestStoreForChatFallsBack(t *testing.T) {
+	client := mocks.NewMockScrapperClient(t)
+	client.On("GetTgChatIdTemplate", mock.Anything, int64(1)).
+		Twice().Return(nil, errors.New("connection refused"))
+
+	store := templates.New(client)
+
+	// Failures are not cached, the next delivery asks again.
+	require.Empty(t, store.ForChat(context.Background(), 1))
+	require.Empty(t, store.ForChat(context.Background(), 1))
+}
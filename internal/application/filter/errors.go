@@ -0,0 +1,17 @@
+package filter
+
+import "fmt"
+
+type filterError struct{ msg string }
+
+func (e filterError) Error() string { return fmt.Sprintf("error: %s", e.msg) }
+
+var (
+	ErrInvalidFormat  = filterError{msg: "filter does not satisfy key:value format"}
+	ErrUnknownKey     = filterError{msg: "unknown filter key"}
+	ErrUnknownType    = filterError{msg: "unknown update type"}
+	ErrUnknownRelease = filterError{msg: "release filter must be one of major, minor, patch, prerelease"}
+
+	ErrInvalidScore      = filterError{msg: "score filter must be a number, optionally after >=, <=, >, < or ="}
+	ErrInvalidUnanswered = filterError{msg: "unanswered filter must be true or false"}
+)
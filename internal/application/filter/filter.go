@@ -0,0 +1,303 @@
+package filter
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+)
+
+const (
+	KeyUser    = "user"
+	KeyType    = "type"
+	KeyLabel   = "label"
+	KeyKeyword = "keyword"
+	KeyRelease = "release"
+
+	KeyScore      = "score"
+	KeyUnanswered = "unanswered"
+)
+
+const (
+	ReleaseMajor      = "major"
+	ReleaseMinor      = "minor"
+	ReleasePatch      = "patch"
+	ReleasePrerelease = "prerelease"
+)
+
+const negation = "-"
+
+var matchers = map[string]func(update *models.Update, value string) bool{
+	KeyUser: func(update *models.Update, value string) bool {
+		return strings.EqualFold(update.Author, value)
+	},
+	KeyType: func(update *models.Update, value string) bool {
+		return update.Kind == models.Kind(value)
+	},
+	KeyLabel: func(update *models.Update, value string) bool {
+		return slices.ContainsFunc(update.Labels, func(label string) bool {
+			return strings.EqualFold(label, value)
+		})
+	},
+	KeyKeyword: func(update *models.Update, value string) bool {
+		text := strings.ToLower(update.Title + " " + update.Body)
+		return strings.Contains(text, strings.ToLower(value))
+	},
+	KeyRelease: func(update *models.Update, value string) bool {
+		return slices.ContainsFunc(releaseLabels[value], func(label string) bool {
+			return slices.Contains(update.Labels, label)
+		})
+	},
+	KeyScore: func(update *models.Update, value string) bool {
+		bound, _ := parseScore(value)
+
+		for _, label := range update.Labels {
+			if raw, ok := strings.CutPrefix(label, models.LabelScore); ok {
+				score, err := strconv.ParseInt(raw, 10, 64)
+				return err == nil && bound.holds(score)
+			}
+		}
+
+		return false
+	},
+	KeyUnanswered: func(update *models.Update, value string) bool {
+		unanswered, _ := strconv.ParseBool(value)
+		return slices.Contains(update.Labels, models.LabelUnanswered) == unanswered
+	},
+}
+
+// releaseLabels lists the version labels each release filter accepts:
+// release:minor lets major releases through as well.
+var releaseLabels = map[string][]string{
+	ReleaseMajor:      {models.LabelMajor},
+	ReleaseMinor:      {models.LabelMajor, models.LabelMinor},
+	ReleasePatch:      {models.LabelMajor, models.LabelMinor, models.LabelPatch},
+	ReleasePrerelease: {models.LabelPrerelease},
+}
+
+// scopes limit keys to the updates they can judge. Rules of a key are
+// ignored for other updates, so release:major still lets issues through.
+var scopes = map[string]func(update *models.Update) bool{
+	KeyRelease: func(update *models.Update) bool {
+		return update.Kind == models.KindRelease || update.Kind == models.KindTag
+	},
+	KeyScore: func(update *models.Update) bool {
+		return update.Kind == models.KindQuestion || update.Kind == models.KindScore
+	},
+	KeyUnanswered: func(update *models.Update) bool {
+		return update.Kind == models.KindQuestion
+	},
+}
+
+var kinds = []models.Kind{
+	models.KindIssue,
+	models.KindPR,
+	models.KindAnswer,
+	models.KindComment,
+	models.KindTag,
+	models.KindPost,
+	models.KindRelease,
+	models.KindCommit,
+	models.KindReview,
+	models.KindReviewComment,
+	models.KindLabel,
+	models.KindState,
+	models.KindWorkflow,
+	models.KindQuestion,
+	models.KindEdit,
+	models.KindAccepted,
+	models.KindUnaccepted,
+	models.KindBounty,
+	models.KindScore,
+}
+
+// Filter decides whether an update should reach the subscriber.
+// Values of the same key are alternatives, different keys must all hold,
+// and a single matching negated rule (e.g. -user:dependabot) rejects the update.
+type Filter struct {
+	include map[string][]string
+	exclude map[string][]string
+}
+
+func Parse(raw []string) (*Filter, error) {
+	f := newFilter()
+
+	for _, item := range raw {
+		if err := f.add(item); err != nil {
+			return nil, err
+		}
+	}
+
+	return f, nil
+}
+
+// ParseStored parses the filters saved with a subscription. Rules an older version
+// accepted but this one does not, such as stars:>500, are left out and returned
+// as errors, so the rest of the filter still applies.
+func ParseStored(raw []string) (*Filter, []error) {
+	f := newFilter()
+
+	var skipped []error
+
+	for _, item := range raw {
+		if err := f.add(item); err != nil {
+			skipped = append(skipped, err)
+		}
+	}
+
+	return f, skipped
+}
+
+func newFilter() *Filter {
+	return &Filter{
+		include: make(map[string][]string),
+		exclude: make(map[string][]string),
+	}
+}
+
+func (f *Filter) add(item string) error {
+	negated := strings.HasPrefix(item, negation)
+
+	key, value, found := strings.Cut(strings.TrimPrefix(item, negation), ":")
+	if !found || key == "" || value == "" {
+		return fmt.Errorf("filter %q: %w", item, ErrInvalidFormat)
+	}
+
+	key = strings.ToLower(key)
+
+	if _, ok := matchers[key]; !ok {
+		return fmt.Errorf("filter %q: %w", item, ErrUnknownKey)
+	}
+
+	if key == KeyType {
+		value = strings.ToLower(value)
+
+		if !slices.Contains(kinds, models.Kind(value)) {
+			return fmt.Errorf("filter %q: %w", item, ErrUnknownType)
+		}
+	}
+
+	if key == KeyRelease {
+		value = strings.ToLower(value)
+
+		if _, ok := releaseLabels[value]; !ok {
+			return fmt.Errorf("filter %q: %w", item, ErrUnknownRelease)
+		}
+	}
+
+	if key == KeyScore {
+		if _, err := parseScore(value); err != nil {
+			return fmt.Errorf("filter %q: %w", item, err)
+		}
+	}
+
+	if key == KeyUnanswered {
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("filter %q: %w", item, ErrInvalidUnanswered)
+		}
+	}
+
+	if negated {
+		f.exclude[key] = append(f.exclude[key], value)
+	} else {
+		f.include[key] = append(f.include[key], value)
+	}
+
+	return nil
+}
+
+func Validate(raw []string) error {
+	_, err := Parse(raw)
+	return err
+}
+
+func (f *Filter) Match(update *models.Update) bool {
+	for key, values := range f.exclude {
+		if !applies(key, update) {
+			continue
+		}
+
+		for _, value := range values {
+			if matchers[key](update, value) {
+				return false
+			}
+		}
+	}
+
+	for key, values := range f.include {
+		if !applies(key, update) {
+			continue
+		}
+
+		if !slices.ContainsFunc(values, func(value string) bool {
+			return matchers[key](update, value)
+		}) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func applies(key string, update *models.Update) bool {
+	scope, ok := scopes[key]
+	return !ok || scope(update)
+}
+
+// scoreBound is a comparison such as >=2 against a question score.
+// A bare number asks for that exact score.
+type scoreBound struct {
+	op    string
+	value int64
+}
+
+var scoreOps = []string{">=", "<=", ">", "<", "="}
+
+func parseScore(raw string) (scoreBound, error) {
+	bound := scoreBound{op: "="}
+
+	for _, op := range scoreOps {
+		if rest, ok := strings.CutPrefix(raw, op); ok {
+			bound.op, raw = op, rest
+			break
+		}
+	}
+
+	value, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return scoreBound{}, ErrInvalidScore
+	}
+
+	bound.value = value
+
+	return bound, nil
+}
+
+func (b scoreBound) holds(score int64) bool {
+	switch b.op {
+	case ">=":
+		return score >= b.value
+	case "<=":
+		return score <= b.value
+	case ">":
+		return score > b.value
+	case "<":
+		return score < b.value
+	}
+
+	return score == b.value
+}
+
+func (f *Filter) Apply(updates []models.Update) []models.Update {
+	sieved := make([]models.Update, 0, len(updates))
+
+	for i := range updates {
+		if f.Match(&updates[i]) {
+			sieved = append(sieved, updates[i])
+		}
+	}
+
+	return sieved
+}
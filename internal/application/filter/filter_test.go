@@ -0,0 +1,272 @@
+package filter_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/filter"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		filters []string
+		wantErr error
+	}{
+		"empty": {
+			filters: nil,
+			wantErr: nil,
+		},
+		"supported keys": {
+			filters: []string{"user:gopher", "type:pr", "label:bug", "keyword:panic"},
+			wantErr: nil,
+		},
+		"negation": {
+			filters: []string{"-user:dependabot"},
+			wantErr: nil,
+		},
+		"value with colon": {
+			filters: []string{"keyword:http://example.com"},
+			wantErr: nil,
+		},
+		"missing value": {
+			filters: []string{"user:"},
+			wantErr: filter.ErrInvalidFormat,
+		},
+		"missing separator": {
+			filters: []string{"userdummy"},
+			wantErr: filter.ErrInvalidFormat,
+		},
+		"unknown key": {
+			filters: []string{"stars:>100"},
+			wantErr: filter.ErrUnknownKey,
+		},
+		"unknown type": {
+			filters: []string{"type:wiki"},
+			wantErr: filter.ErrUnknownType,
+		},
+		"release levels": {
+			filters: []string{"release:major", "release:Minor", "-release:prerelease"},
+			wantErr: nil,
+		},
+		"unknown release level": {
+			filters: []string{"release:huge"},
+			wantErr: filter.ErrUnknownRelease,
+		},
+		"score bounds": {
+			filters: []string{"score:>=2", "-score:<0", "score:5"},
+			wantErr: nil,
+		},
+		"score without number": {
+			filters: []string{"score:>=high"},
+			wantErr: filter.ErrInvalidScore,
+		},
+		"unanswered not a boolean": {
+			filters: []string{"unanswered:maybe"},
+			wantErr: filter.ErrInvalidUnanswered,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := filter.Parse(test.filters)
+			if test.wantErr != nil {
+				require.ErrorIs(t, err, test.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	update := models.NewUpdate(
+		"42",
+		models.KindPR,
+		"Fix panic in scheduler",
+		"https://github.com/example/repo/pull/42",
+		time.Date(2025, 5, 16, 10, 0, 0, 0, time.UTC),
+		"dependabot",
+		"Bumps go-redis to v9.9.0",
+		"dependencies", "Go",
+	)
+
+	tests := map[string]struct {
+		filters  []string
+		expected bool
+	}{
+		"no filters": {
+			filters:  nil,
+			expected: true,
+		},
+		"matching user": {
+			filters:  []string{"user:Dependabot"},
+			expected: true,
+		},
+		"other user": {
+			filters:  []string{"user:gopher"},
+			expected: false,
+		},
+		"negated user": {
+			filters:  []string{"-user:dependabot"},
+			expected: false,
+		},
+		"negated other user": {
+			filters:  []string{"-user:gopher"},
+			expected: true,
+		},
+		"alternative types": {
+			filters:  []string{"type:issue", "type:pr"},
+			expected: true,
+		},
+		"label case insensitive": {
+			filters:  []string{"label:go"},
+			expected: true,
+		},
+		"keyword in preview": {
+			filters:  []string{"keyword:REDIS"},
+			expected: true,
+		},
+		"all keys must hold": {
+			filters:  []string{"type:pr", "label:bug"},
+			expected: false,
+		},
+		"negation wins": {
+			filters:  []string{"type:pr", "-keyword:panic"},
+			expected: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f, err := filter.Parse(test.filters)
+			require.NoError(t, err)
+
+			require.Equal(t, test.expected, f.Match(&update))
+		})
+	}
+}
+
+func TestMatchRelease(t *testing.T) {
+	major := models.NewUpdate("1", models.KindRelease, "v2.0.0", "", time.Time{}, "", "", models.LabelMajor)
+	minor := models.NewUpdate("2", models.KindRelease, "v1.5.0", "", time.Time{}, "", "", models.LabelMinor)
+	candidate := models.NewUpdate("3", models.KindTag, "v3.0.0-rc1", "", time.Time{}, "", "",
+		models.LabelMajor, models.LabelPrerelease)
+	unversioned := models.NewUpdate("4", models.KindTag, "nightly", "", time.Time{}, "", "")
+	issue := models.NewUpdate("5", models.KindIssue, "Crash", "", time.Time{}, "", "")
+
+	tests := map[string]struct {
+		filters  []string
+		expected []string
+	}{
+		"major only": {
+			filters:  []string{"release:major"},
+			expected: []string{"1", "3", "5"},
+		},
+		"minor includes major": {
+			filters:  []string{"release:minor"},
+			expected: []string{"1", "2", "3", "5"},
+		},
+		"skip pre-releases": {
+			filters:  []string{"-release:prerelease"},
+			expected: []string{"1", "2", "4", "5"},
+		},
+		"stable majors": {
+			filters:  []string{"release:major", "-release:prerelease"},
+			expected: []string{"1", "5"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f, err := filter.Parse(test.filters)
+			require.NoError(t, err)
+
+			ids := make([]string, 0, len(test.expected))
+			for _, update := range f.Apply([]models.Update{major, minor, candidate, unversioned, issue}) {
+				ids = append(ids, update.ID)
+			}
+
+			require.Equal(t, test.expected, ids)
+		})
+	}
+}
+
+func TestMatchQuestion(t *testing.T) {
+	popular := models.NewUpdate("1", models.KindQuestion, "pgx pool", "", time.Time{}, "", "",
+		"go", "pgx", models.LabelScore+"3", models.LabelUnanswered)
+	answered := models.NewUpdate("2", models.KindQuestion, "pgx copy", "", time.Time{}, "", "",
+		"go", "pgx", models.LabelScore+"2")
+	downvoted := models.NewUpdate("3", models.KindQuestion, "pgx help", "", time.Time{}, "", "",
+		"go", "pgx", models.LabelScore+"-1", models.LabelUnanswered)
+	answer := models.NewUpdate("4", models.KindAnswer, "answer", "", time.Time{}, "", "")
+	milestone := models.NewUpdate("5", models.KindScore, "pgx pool reached a score of 100", "", time.Time{}, "", "",
+		models.LabelScore+"104")
+
+	tests := map[string]struct {
+		filters  []string
+		expected []string
+	}{
+		"score at least": {
+			filters:  []string{"score:>=2"},
+			expected: []string{"1", "2", "4", "5"},
+		},
+		"exact score": {
+			filters:  []string{"score:-1"},
+			expected: []string{"3", "4"},
+		},
+		"unanswered": {
+			filters:  []string{"unanswered:true"},
+			expected: []string{"1", "3", "4", "5"},
+		},
+		"answered and not negative": {
+			filters:  []string{"unanswered:false", "-score:<0"},
+			expected: []string{"2", "4", "5"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f, err := filter.Parse(test.filters)
+			require.NoError(t, err)
+
+			ids := make([]string, 0, len(test.expected))
+			for _, update := range f.Apply([]models.Update{popular, answered, downvoted, answer, milestone}) {
+				ids = append(ids, update.ID)
+			}
+
+			require.Equal(t, test.expected, ids)
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	updates := []models.Update{
+		models.NewUpdate("1", models.KindPR, "Bump deps", "", time.Time{}, "dependabot", ""),
+		models.NewUpdate("2", models.KindIssue, "Crash on start", "", time.Time{}, "gopher", ""),
+		models.NewUpdate("3", models.KindPR, "Fix crash", "", time.Time{}, "gopher", ""),
+	}
+
+	f, err := filter.Parse([]string{"-user:dependabot", "type:pr"})
+	require.NoError(t, err)
+
+	sieved := f.Apply(updates)
+	require.Len(t, sieved, 1)
+	require.Equal(t, "Fix crash", sieved[0].Title)
+}
+
+func TestParseStored(t *testing.T) {
+	updates := []models.Update{
+		models.NewUpdate("1", models.KindPR, "Bump deps", "", time.Time{}, "dependabot", ""),
+		models.NewUpdate("2", models.KindPR, "Fix crash", "", time.Time{}, "gopher", ""),
+	}
+
+	f, skipped := filter.ParseStored([]string{"stars:>500", "-user:dependabot", "license:apache"})
+	require.Len(t, skipped, 2)
+	require.ErrorIs(t, skipped[0], filter.ErrUnknownKey)
+
+	sieved := f.Apply(updates)
+	require.Len(t, sieved, 1)
+	require.Equal(t, "Fix crash", sieved[0].Title)
+}
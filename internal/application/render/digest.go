@@ -0,0 +1,115 @@
+package render
+
+import (
+	"fmt"
+	"html"
+	"log/slog"
+	"strings"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+)
+
+// titleLength bounds an item title in a digest, in runes.
+const titleLength = 120
+
+// Digest renders the digest of a chat: a section per link with the number of updates
+// of each kind, the latest of them and a line for the ones left out.
+func Digest(digest models.ChatDigest) []string {
+	return compose(digest, item)
+}
+
+// DigestWithTemplate renders the digest with each of its updates laid out by the template
+// of the chat. An update the template fails on gets the default line, and the whole digest
+// keeps the default layout when the chat has no template or it does not parse.
+func DigestWithTemplate(text string, digest models.ChatDigest) []string {
+	if strings.TrimSpace(text) == "" {
+		return Digest(digest)
+	}
+
+	t, err := compile(text)
+	if err != nil {
+		slog.Warn("render: chat template failed, using the default digest layout",
+			slog.Int64("chat_id", digest.ChatID),
+			slog.String("error", err.Error()),
+		)
+
+		return Digest(digest)
+	}
+
+	return compose(digest, func(link string, update models.Update) string {
+		message, err := t.Update(link, update)
+		if err != nil {
+			slog.Warn("render: chat template failed, using the default digest line",
+				slog.String("url", update.URL),
+				slog.String("error", err.Error()),
+			)
+
+			return item(link, update)
+		}
+
+		return message
+	})
+}
+
+func compose(digest models.ChatDigest, layout func(link string, update models.Update) string) []string {
+	total := 0
+	for _, link := range digest.Links {
+		total += link.Total
+	}
+
+	lines := []string{fmt.Sprintf("📬 <b>Digest</b>: %s from %s",
+		count(total, "update"), count(len(digest.Links), "link"))}
+
+	for _, link := range digest.Links {
+		lines = append(lines, "", "🔗 "+anchor(link.URL, link.URL))
+
+		kinds := make([]string, 0, len(link.Counts))
+		for _, c := range link.Counts {
+			kinds = append(kinds, fmt.Sprintf("%s ×%d", html.EscapeString(string(c.Kind)), c.Count))
+		}
+
+		lines = append(lines, "📊 "+strings.Join(kinds, " · "))
+
+		for _, update := range link.Updates {
+			// A template may lay an update out over several lines, a blank one keeps them apart.
+			text := layout(link.URL, update)
+			if strings.Contains(text, "\n") {
+				lines = append(lines, "")
+			}
+
+			lines = append(lines, text)
+		}
+
+		if more := link.More(); more > 0 {
+			lines = append(lines, fmt.Sprintf("+%d more", more))
+		}
+	}
+
+	return Split(strings.Join(lines, "\n"))
+}
+
+func item(_ string, update models.Update) string {
+	title := update.Title
+	if title == "" {
+		title = string(update.Kind)
+	}
+
+	if runes := []rune(title); len(runes) > titleLength {
+		title = string(runes[:titleLength]) + "…"
+	}
+
+	line := "• " + anchor(update.URL, title)
+	if update.Author != "" {
+		line += " · 👤 " + html.EscapeString(update.Author)
+	}
+
+	return line
+}
+
+func count(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,116 @@
+package render_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/render"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDigest(t *testing.T) {
+	digest := models.ChatDigest{
+		ChatID: 1,
+		Links: []models.LinkDigest{
+			{
+				URL:   "https://github.com/example/repo",
+				Total: 3,
+				Counts: []models.KindCount{
+					{Kind: models.KindPR, Count: 2},
+					{Kind: models.KindIssue, Count: 1},
+				},
+				Updates: []models.Update{
+					{Kind: models.KindPR, Title: "Fix <nil> deref", URL: "https://github.com/example/repo/pull/2", Author: "gopher"},
+					{Kind: models.KindIssue, URL: "https://github.com/example/repo/issues/1"},
+				},
+			},
+			{
+				URL:     "https://stackoverflow.com/questions/1",
+				Total:   1,
+				Counts:  []models.KindCount{{Kind: models.KindAnswer, Count: 1}},
+				Updates: []models.Update{{Kind: models.KindAnswer, Title: "Use a mutex", URL: "https://stackoverflow.com/a/2"}},
+			},
+		},
+	}
+
+	expected := strings.Join([]string{
+		"📬 <b>Digest</b>: 4 updates from 2 links",
+		"",
+		`🔗 <a href="https://github.com/example/repo">https://github.com/example/repo</a>`,
+		"📊 pr ×2 · issue ×1",
+		`• <a href="https://github.com/example/repo/pull/2">Fix &lt;nil&gt; deref</a> · 👤 gopher`,
+		`• <a href="https://github.com/example/repo/issues/1">issue</a>`,
+		"+1 more",
+		"",
+		`🔗 <a href="https://stackoverflow.com/questions/1">https://stackoverflow.com/questions/1</a>`,
+		"📊 answer ×1",
+		`• <a href="https://stackoverflow.com/a/2">Use a mutex</a>`,
+	}, "\n")
+
+	require.Equal(t, []string{expected}, render.Digest(digest))
+}
+
+func TestDigestWithTemplate(t *testing.T) {
+	digest := models.ChatDigest{
+		ChatID: 1,
+		Links: []models.LinkDigest{{
+			URL:    "https://github.com/example/repo",
+			Total:  2,
+			Counts: []models.KindCount{{Kind: models.KindIssue, Count: 2}},
+			Updates: []models.Update{
+				{Kind: models.KindIssue, Title: "Crash", URL: "https://github.com/example/repo/issues/2", Labels: []string{"bug"}},
+				{Kind: models.KindIssue, Title: "Docs", URL: "https://github.com/example/repo/issues/1"},
+			},
+		}},
+	}
+
+	header := []string{
+		"📬 <b>Digest</b>: 2 updates from 1 link",
+		"",
+		`🔗 <a href="https://github.com/example/repo">https://github.com/example/repo</a>`,
+		"📊 issue ×2",
+	}
+
+	tests := map[string]struct {
+		template string
+		items    []string
+	}{
+		"no template": {
+			template: "",
+			items: []string{
+				`• <a href="https://github.com/example/repo/issues/2">Crash</a>`,
+				`• <a href="https://github.com/example/repo/issues/1">Docs</a>`,
+			},
+		},
+		"every update laid out by the template": {
+			template: "<i>{{.Kind}}</i> {{.Title}} in {{.Link}}",
+			items: []string{
+				"<i>issue</i> Crash in https://github.com/example/repo",
+				"<i>issue</i> Docs in https://github.com/example/repo",
+			},
+		},
+		"update the template fails on keeps the default line": {
+			template: "{{.Title}} #{{index .Labels 0}}",
+			items: []string{
+				"Crash #bug",
+				`• <a href="https://github.com/example/repo/issues/1">Docs</a>`,
+			},
+		},
+		"broken template keeps the default layout": {
+			template: "{{.Title",
+			items: []string{
+				`• <a href="https://github.com/example/repo/issues/2">Crash</a>`,
+				`• <a href="https://github.com/example/repo/issues/1">Docs</a>`,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			expected := strings.Join(append(slices.Clone(header), test.items...), "\n")
+			require.Equal(t, []string{expected}, render.DigestWithTemplate(test.template, digest))
+		})
+	}
+}
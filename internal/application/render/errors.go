@@ -0,0 +1,15 @@
+package render
+
+import "fmt"
+
+type renderError struct{ msg string }
+
+func (e renderError) Error() string { return fmt.Sprintf("error: %s", e.msg) }
+
+var (
+	ErrTemplateTooLong   = renderError{msg: "template is too long"}
+	ErrTemplateEmpty     = renderError{msg: "template renders to an empty message"}
+	ErrTemplateOverflow  = renderError{msg: "template renders to an oversized message"}
+	ErrTemplateMalformed = renderError{msg: "template renders to malformed markup"}
+	ErrTemplateUnbounded = renderError{msg: "template loops over more than a field or calls other templates"}
+)
@@ -0,0 +1,153 @@
+package render
+
+import (
+	"html"
+	"strings"
+)
+
+// inlineTags maps the formatting tags of a body to the ones Telegram accepts.
+var inlineTags = map[string]string{
+	"b":      "b",
+	"strong": "b",
+	"i":      "i",
+	"em":     "i",
+	"u":      "u",
+	"ins":    "u",
+	"s":      "s",
+	"del":    "s",
+	"strike": "s",
+	"code":   "code",
+	"pre":    "pre",
+}
+
+// blockTags break the line around themselves, paragraphs with an empty line.
+var blockTags = map[string]int{
+	"p":          2,
+	"div":        1,
+	"blockquote": 2,
+	"ul":         1,
+	"ol":         1,
+	"table":      1,
+	"tr":         1,
+	"hr":         2,
+	"h1":         2,
+	"h2":         2,
+	"h3":         2,
+	"h4":         2,
+	"h5":         2,
+	"h6":         2,
+}
+
+// fromHTML converts an HTML body, such as the ones StackExchange and feeds send,
+// into Telegram HTML. Unknown tags are dropped with their text kept, and a tag
+// cut off by the excerpt ends the body.
+func fromHTML(w *writer, body string) {
+	for body != "" {
+		start := strings.IndexByte(body, '<')
+		if start < 0 {
+			w.text(html.UnescapeString(body))
+			return
+		}
+
+		w.text(html.UnescapeString(body[:start]))
+		body = body[start:]
+
+		end := strings.IndexByte(body, '>')
+		if end < 0 {
+			return
+		}
+
+		tag := body[1:end]
+		body = body[end+1:]
+
+		// Comments and doctypes carry nothing to show.
+		if strings.HasPrefix(tag, "!") || strings.HasPrefix(tag, "?") {
+			continue
+		}
+
+		closing := strings.HasPrefix(tag, "/")
+		name, attrs := tagName(strings.TrimPrefix(tag, "/"))
+
+		switch {
+		case name == "br":
+			w.newline(1)
+		case name == "li":
+			if !closing {
+				w.newline(1)
+				w.text("• ")
+			}
+		case name == "a":
+			if closing {
+				w.closeTag("a")
+			} else if href := attr(attrs, "href"); isWebLink(href) {
+				w.openTag("a", href)
+			}
+		case strings.HasPrefix(name, "h") && blockTags[name] > 0:
+			w.newline(blockTags[name])
+
+			if closing {
+				w.closeTag("b")
+			} else {
+				w.openTag("b", "")
+			}
+		case blockTags[name] > 0:
+			w.newline(blockTags[name])
+		case inlineTags[name] != "":
+			if name == "pre" {
+				w.newline(1)
+			}
+
+			if closing {
+				w.closeTag(inlineTags[name])
+			} else if !w.inside("pre") {
+				w.openTag(inlineTags[name], "")
+			}
+
+			if name == "pre" && closing {
+				w.newline(1)
+			}
+		}
+	}
+}
+
+func tagName(tag string) (string, string) {
+	tag = strings.TrimSuffix(strings.TrimSpace(tag), "/")
+
+	name, attrs, _ := strings.Cut(tag, " ")
+
+	return strings.ToLower(strings.TrimSpace(name)), attrs
+}
+
+// attr reads a quoted attribute value, which is all the bodies here use.
+func attr(attrs, name string) string {
+	for attrs != "" {
+		i := strings.Index(strings.ToLower(attrs), name+"=")
+		if i < 0 {
+			return ""
+		}
+
+		if i > 0 && !isSpace(rune(attrs[i-1])) {
+			attrs = attrs[i+len(name)+1:]
+			continue
+		}
+
+		rest := attrs[i+len(name)+1:]
+		if rest == "" || (rest[0] != '"' && rest[0] != '\'') {
+			return ""
+		}
+
+		value, _, found := strings.Cut(rest[1:], rest[:1])
+		if !found {
+			return ""
+		}
+
+		return html.UnescapeString(value)
+	}
+
+	return ""
+}
+
+func isWebLink(href string) bool {
+	lower := strings.ToLower(href)
+	return strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "http://")
+}
@@ -0,0 +1,167 @@
+package render
+
+import (
+	"strings"
+)
+
+// markers are the inline Markdown spans turned into tags, longest first.
+// Underscores are left alone, they show up in identifiers far more often
+// than as emphasis.
+var markers = []struct {
+	marker string
+	tag    string
+}{
+	{"**", "b"},
+	{"__", "b"},
+	{"~~", "s"},
+	{"*", "i"},
+}
+
+// fromMarkdown converts the GitHub flavoured Markdown of issues, pull requests and
+// releases into Telegram HTML. It covers fenced code, headings, lists, quotes,
+// emphasis, inline code and links, anything else is shown as written.
+func fromMarkdown(w *writer, body string) {
+	lines := strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+	fenced := false
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			if fenced {
+				w.closeTag("pre")
+				w.newline(1)
+			} else {
+				w.newline(1)
+				w.openTag("pre", "")
+			}
+
+			fenced = !fenced
+
+			continue
+		}
+
+		if fenced {
+			w.text(line + "\n")
+			continue
+		}
+
+		switch {
+		case trimmed == "":
+			w.newline(2)
+			continue
+		case strings.HasPrefix(trimmed, "#"):
+			w.newline(2)
+			w.openTag("b", "")
+			inline(w, strings.TrimSpace(strings.TrimLeft(trimmed, "#")))
+			w.closeTag("b")
+			w.newline(2)
+
+			continue
+		case isListItem(trimmed):
+			w.newline(1)
+			w.text("• ")
+			trimmed = strings.TrimSpace(trimmed[2:])
+		case strings.HasPrefix(trimmed, ">"):
+			w.newline(1)
+			w.text("│ ")
+			trimmed = strings.TrimSpace(strings.TrimPrefix(trimmed, ">"))
+		default:
+			w.newline(1)
+		}
+
+		inline(w, trimmed)
+	}
+}
+
+func isListItem(line string) bool {
+	return strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* ") || strings.HasPrefix(line, "+ ")
+}
+
+// inline converts the spans of a single line. An opening marker without
+// a closing one on the same line is kept as text.
+func inline(w *writer, line string) {
+	var text strings.Builder
+
+	flush := func() {
+		w.text(text.String())
+		text.Reset()
+	}
+
+	for line != "" {
+		if strings.HasPrefix(line, "`") {
+			if code, rest, ok := strings.Cut(line[1:], "`"); ok && code != "" {
+				flush()
+				w.openTag("code", "")
+				w.text(code)
+				w.closeTag("code")
+
+				line = rest
+
+				continue
+			}
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if label, href, rest, ok := link(line); ok {
+				flush()
+				w.openTag("a", href)
+				inline(w, label)
+				w.closeTag("a")
+
+				line = rest
+
+				continue
+			}
+		}
+
+		if span, tag, rest, ok := emphasis(line); ok {
+			flush()
+			w.openTag(tag, "")
+			inline(w, span)
+			w.closeTag(tag)
+
+			line = rest
+
+			continue
+		}
+
+		r := []rune(line)[0]
+		text.WriteRune(r)
+		line = line[len(string(r)):]
+	}
+
+	flush()
+}
+
+// link reads [label](href) with a web address.
+func link(line string) (string, string, string, bool) {
+	label, rest, ok := strings.Cut(line[1:], "](")
+	if !ok || label == "" || strings.Contains(label, "[") {
+		return "", "", "", false
+	}
+
+	href, rest, ok := strings.Cut(rest, ")")
+	if !ok || !isWebLink(href) {
+		return "", "", "", false
+	}
+
+	return label, href, rest, true
+}
+
+func emphasis(line string) (string, string, string, bool) {
+	for _, m := range markers {
+		if !strings.HasPrefix(line, m.marker) {
+			continue
+		}
+
+		span, rest, ok := strings.Cut(line[len(m.marker):], m.marker)
+		if !ok || span == "" || span != strings.TrimSpace(span) {
+			return "", "", "", false
+		}
+
+		return span, m.tag, rest, true
+	}
+
+	return "", "", "", false
+}
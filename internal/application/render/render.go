@@ -0,0 +1,189 @@
+// Package render turns updates into Telegram messages in HTML parse mode.
+// Bodies are converted from the markup their source uses, all user content
+// is escaped, and messages over the Telegram limit are split.
+package render
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/es-debug/backend-academy-2024-go-template/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+)
+
+const (
+	// MaxLength is the longest text Telegram accepts in one message.
+	MaxLength = 4096
+	// ExcerptLength bounds the visible part of a body, in runes.
+	ExcerptLength = 500
+)
+
+// Update renders one update of the tracked link as one or more messages.
+func Update(link string, update models.Update) []string {
+	return Split(strings.Join(Blocks(link, update), "\n"))
+}
+
+// Blocks renders the parts of an update message. Every block is well-formed HTML
+// on its own, so messages can be split between them.
+func Blocks(link string, update models.Update) []string {
+	blocks := []string{
+		"✨ New update via " + anchor(link, link),
+		"📌 <b>" + html.EscapeString(update.Title) + "</b>",
+	}
+
+	meta := "🕒 " + update.CreatedAt.UTC().Format(time.RFC3339)
+	if update.Author != "" {
+		meta += " · 👤 " + html.EscapeString(update.Author)
+	}
+
+	blocks = append(blocks, meta)
+
+	if body := Body(update); body != "" {
+		blocks = append(blocks, "", body, "")
+	}
+
+	if update.URL != "" {
+		blocks = append(blocks, "🔗 "+anchor(update.URL, "View"))
+	}
+
+	return blocks
+}
+
+// Body converts the body excerpt of an update from the markup of its source.
+func Body(update models.Update) string {
+	w := newWriter(ExcerptLength)
+
+	switch update.Source {
+	case config.GitHub, config.GitLab:
+		fromMarkdown(w, update.Body)
+	default:
+		fromHTML(w, update.Body)
+	}
+
+	return w.String()
+}
+
+func anchor(href, text string) string {
+	if !isWebLink(href) {
+		return html.EscapeString(text)
+	}
+
+	return `<a href="` + html.EscapeString(href) + `">` + html.EscapeString(text) + "</a>"
+}
+
+// Split packs lines into messages of at most MaxLength runes. A line too long
+// for one message loses its markup and is cut between characters.
+func Split(text string) []string {
+	var (
+		messages []string
+		current  strings.Builder
+		length   int
+	)
+
+	flush := func() {
+		if message := strings.TrimSpace(current.String()); message != "" {
+			messages = append(messages, message)
+		}
+
+		current.Reset()
+		length = 0
+	}
+
+	for _, line := range splitLines(text) {
+		n := len([]rune(line))
+
+		if length > 0 && length+1+n > MaxLength {
+			flush()
+		}
+
+		if n > MaxLength {
+			for _, chunk := range chunks(line) {
+				flush()
+				current.WriteString(chunk)
+				length = len([]rune(chunk))
+			}
+
+			continue
+		}
+
+		if length > 0 {
+			current.WriteString("\n")
+			length++
+		}
+
+		current.WriteString(line)
+		length += n
+	}
+
+	flush()
+
+	return messages
+}
+
+// splitLines splits at line breaks outside of pre blocks, a code block is kept
+// in one piece as long as it fits a message.
+func splitLines(text string) []string {
+	var lines []string
+
+	for text != "" {
+		end := strings.IndexByte(text, '\n')
+		if end < 0 {
+			end = len(text)
+		}
+
+		line := text[:end]
+
+		for strings.Count(line, "<pre>") > strings.Count(line, "</pre>") && end < len(text) {
+			next := strings.IndexByte(text[end+1:], '\n')
+			if next < 0 {
+				end = len(text)
+			} else {
+				end += 1 + next
+			}
+
+			line = text[:end]
+		}
+
+		lines = append(lines, line)
+		text = strings.TrimPrefix(text[end:], "\n")
+	}
+
+	return lines
+}
+
+var tags = regexp.MustCompile(`<[^>]*>`)
+
+// chunks cuts a line into plain text pieces that fit a message,
+// never in the middle of an escaped character.
+func chunks(line string) []string {
+	plain := html.UnescapeString(tags.ReplaceAllString(line, ""))
+
+	var (
+		pieces []string
+		piece  strings.Builder
+		length int
+	)
+
+	for _, r := range plain {
+		escaped := html.EscapeString(string(r))
+		n := utf8.RuneCountInString(escaped)
+
+		if length+n > MaxLength {
+			pieces = append(pieces, piece.String())
+			piece.Reset()
+			length = 0
+		}
+
+		piece.WriteString(escaped)
+		length += n
+	}
+
+	if piece.Len() > 0 {
+		pieces = append(pieces, piece.String())
+	}
+
+	return pieces
+}
@@ -0,0 +1,112 @@
+package render_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/render"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBody(t *testing.T) {
+	tests := map[string]struct {
+		source   string
+		body     string
+		expected string
+	}{
+		"stackexchange html": {
+			source: config.StackOverflow,
+			body: `<p>How do I use <code>pgx.Conn</code> with <strong>5 &lt; 6</strong>?</p>
+<pre><code>conn, err := pgx.Connect(ctx, "a&amp;b")
+</code></pre>
+<ul><li>one</li><li>two</li></ul>`,
+			expected: "How do I use <code>pgx.Conn</code> with <b>5 &lt; 6</b>?\n\n" +
+				"<pre>conn, err := pgx.Connect(ctx, &#34;a&amp;b&#34;)\n</pre>\n• one\n• two",
+		},
+		"unsafe tags and relative links are dropped": {
+			source:   config.StackOverflow,
+			body:     `<script>alert(1)</script><a href="/users/1">me</a> and <a href="javascript:x">you</a> <img src="x">`,
+			expected: "alert(1)me and you",
+		},
+		"absolute links are kept": {
+			source:   config.Feed,
+			body:     `see <a href="https://go.dev/?a=1&amp;b=2" rel="nofollow">the docs</a>`,
+			expected: `see <a href="https://go.dev/?a=1&amp;b=2">the docs</a>`,
+		},
+		"tag cut off by the excerpt": {
+			source:   config.StackOverflow,
+			body:     `<p>Unclosed <b>bold text <a href="https://exa`,
+			expected: "Unclosed <b>bold text</b>",
+		},
+		"github markdown": {
+			source: config.GitHub,
+			body: "## Steps\n\n- run `go test`\n- see **panic** in [logs](https://ci.example.com/1)\n\n" +
+				"```go\nif a < b {\n}\n```\n<details>raw html</details>",
+			expected: "<b>Steps</b>\n\n• run <code>go test</code>\n" +
+				"• see <b>panic</b> in <a href=\"https://ci.example.com/1\">logs</a>\n\n" +
+				"<pre>if a &lt; b {\n}\n</pre>\n&lt;details&gt;raw html&lt;/details&gt;",
+		},
+		"identifiers keep their underscores and stars": {
+			source:   config.GitLab,
+			body:     "set max_conn_idle to 2 * 3 * 4",
+			expected: "set max_conn_idle to 2 * 3 * 4",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			update := models.Update{Source: test.source, Body: test.body}
+			require.Equal(t, test.expected, render.Body(update))
+		})
+	}
+}
+
+func TestBodyExcerpt(t *testing.T) {
+	update := models.Update{
+		Source: config.StackOverflow,
+		Body:   "<p><i>" + strings.Repeat("a", render.ExcerptLength+100) + "</i></p>",
+	}
+
+	body := render.Body(update)
+
+	require.True(t, strings.HasPrefix(body, "<i>"))
+	require.True(t, strings.HasSuffix(body, "a…</i>"))
+	require.Len(t, []rune(body), len("<i>")+render.ExcerptLength+len([]rune("…</i>")))
+}
+
+func TestUpdate(t *testing.T) {
+	update := models.NewUpdate("1", models.KindIssue, "Crash <on> start", "https://github.com/example/repo/issues/1",
+		time.Date(2025, 6, 7, 9, 0, 0, 0, time.UTC), "gopher", "It **panics**")
+	update.Source = config.GitHub
+
+	messages := render.Update("https://github.com/example/repo", update)
+
+	require.Equal(t, []string{`✨ New update via <a href="https://github.com/example/repo">https://github.com/example/repo</a>
+📌 <b>Crash &lt;on&gt; start</b>
+🕒 2025-06-07T09:00:00Z · 👤 gopher
+
+It <b>panics</b>
+
+🔗 <a href="https://github.com/example/repo/issues/1">View</a>`}, messages)
+}
+
+func TestSplit(t *testing.T) {
+	line := strings.Repeat("word ", 500)
+	code := "<pre>" + strings.Repeat("x := 1\n", 100) + "</pre>"
+
+	messages := render.Split(strings.Join([]string{line, line, code, strings.Repeat("&amp;", 5000)}, "\n"))
+
+	for _, message := range messages {
+		require.LessOrEqual(t, len([]rune(message)), render.MaxLength)
+		require.Equal(t, strings.Count(message, "<pre>"), strings.Count(message, "</pre>"))
+	}
+
+	require.Len(t, messages, 9)
+	require.Equal(t, strings.TrimSpace(line), messages[0])
+	require.Equal(t, line+"\n"+code, messages[1])
+	require.True(t, strings.HasPrefix(messages[2], "&amp;&amp;"))
+	require.True(t, strings.HasSuffix(messages[8], "&amp;"))
+}
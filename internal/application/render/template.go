@@ -0,0 +1,389 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	"log/slog"
+	"regexp"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+	"unicode/utf8"
+
+	"github.com/es-debug/backend-academy-2024-go-template/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+	"github.com/es-debug/backend-academy-2024-go-template/pkg/lru"
+)
+
+// Template is a chat's own layout of an update message, written with text/template.
+// Its output is Telegram HTML limited to the tags the default layout uses.
+type Template struct {
+	tmpl *template.Template
+}
+
+// ParseTemplate parses the text of a template and renders a sample update with it,
+// so a template that parses but cannot render a typical update is rejected early.
+func ParseTemplate(text string) (*Template, error) {
+	if utf8.RuneCountInString(text) > models.MaxTemplateLength {
+		return nil, ErrTemplateTooLong
+	}
+
+	tmpl, err := template.New("update").Funcs(funcs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("render: failed to parse template: %w", err)
+	}
+
+	if err := bounded(tmpl); err != nil {
+		return nil, err
+	}
+
+	t := &Template{tmpl: tmpl}
+
+	if _, err := t.Update(sampleLink, Sample()); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// Update renders one update of the tracked link as a single message.
+func (t *Template) Update(link string, update models.Update) (string, error) {
+	out := &limitedBuffer{limit: MaxLength * utf8.UTFMax}
+
+	if err := t.tmpl.Execute(out, newView(link, update)); err != nil {
+		if errors.Is(err, ErrTemplateOverflow) {
+			return "", ErrTemplateOverflow
+		}
+
+		return "", fmt.Errorf("render: failed to execute template: %w", err)
+	}
+
+	text := strings.TrimSpace(out.String())
+
+	switch {
+	case text == "":
+		return "", ErrTemplateEmpty
+	case utf8.RuneCountInString(text) > MaxLength:
+		return "", ErrTemplateOverflow
+	}
+
+	if err := wellFormed(text); err != nil {
+		return "", err
+	}
+
+	return text, nil
+}
+
+// Preview renders the sample update with a template, as shown when it is saved.
+func Preview(text string) (string, error) {
+	t, err := ParseTemplate(text)
+	if err != nil {
+		return "", err
+	}
+
+	return t.Update(sampleLink, Sample())
+}
+
+// WithTemplate renders an update with the template of a chat. The default layout
+// is used when the chat has no template or it fails on this update.
+func WithTemplate(text, link string, update models.Update) []string {
+	if strings.TrimSpace(text) == "" {
+		return Update(link, update)
+	}
+
+	t, err := compile(text)
+	if err == nil {
+		var message string
+
+		if message, err = t.Update(link, update); err == nil {
+			return []string{message}
+		}
+	}
+
+	slog.Warn("render: chat template failed, using the default layout",
+		slog.String("url", update.URL),
+		slog.String("error", err.Error()),
+	)
+
+	return Update(link, update)
+}
+
+// parsedCacheSize bounds the templates kept parsed, one per chat with its own layout.
+const parsedCacheSize = 1024
+
+type parsedTemplate struct {
+	tmpl *Template
+	err  error
+}
+
+// parsed holds templates by their text, so the updates of a chat do not parse
+// its template and render the sample again each time. Failures are kept too.
+var parsed = lru.New[string, parsedTemplate](parsedCacheSize)
+
+func compile(text string) (*Template, error) {
+	if cached, ok := parsed.Get(text); ok {
+		return cached.tmpl, cached.err
+	}
+
+	t, err := ParseTemplate(text)
+	parsed.Put(text, parsedTemplate{tmpl: t, err: err})
+
+	return t, err
+}
+
+// Sample is the update templates are validated and previewed with.
+func Sample() models.Update {
+	update := models.NewUpdate("sample", models.KindIssue, "Bot crashes on start without a config file",
+		"https://github.com/golang/go/issues/1", time.Date(2025, 6, 7, 9, 0, 0, 0, time.UTC), "gopher",
+		"The bot **panics** on start when `config.yaml` is missing.", "bug", "help wanted")
+	update.Source = config.GitHub
+
+	return update
+}
+
+const sampleLink = "https://github.com/golang/go"
+
+// view is what a template is executed with. Its text is escaped beforehand and
+// Body is already converted to Telegram HTML, so a template adds only its own markup.
+type view struct {
+	Link      string
+	Source    string
+	Kind      string
+	Title     string
+	URL       string
+	Author    string
+	Labels    []string
+	Body      string
+	Date      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func newView(link string, update models.Update) view {
+	labels := make([]string, 0, len(update.Labels))
+	for _, label := range update.Labels {
+		labels = append(labels, html.EscapeString(label))
+	}
+
+	return view{
+		Link:      html.EscapeString(link),
+		Source:    html.EscapeString(update.Source),
+		Kind:      html.EscapeString(string(update.Kind)),
+		Title:     html.EscapeString(update.Title),
+		URL:       html.EscapeString(update.URL),
+		Author:    html.EscapeString(update.Author),
+		Labels:    labels,
+		Body:      Body(update),
+		Date:      update.CreatedAt.UTC().Format(time.RFC3339),
+		CreatedAt: update.CreatedAt,
+		UpdatedAt: update.UpdatedAt,
+	}
+}
+
+var funcs = template.FuncMap{
+	"join":     strings.Join,
+	"truncate": truncate,
+	"date":     date,
+	"printf":   printf,
+}
+
+// bounded rejects what lets a short template run for long: ranging over anything
+// but a field such as .Labels, a range inside another and templates invoking
+// templates. The rest of a template runs in time linear in its size and the update.
+func bounded(tmpl *template.Template) error {
+	if len(tmpl.Templates()) > 1 {
+		return ErrTemplateUnbounded
+	}
+
+	if tmpl.Tree == nil {
+		return nil
+	}
+
+	return walk(tmpl.Tree.Root, false)
+}
+
+func walk(node parse.Node, ranging bool) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+
+		for _, child := range n.Nodes {
+			if err := walk(child, ranging); err != nil {
+				return err
+			}
+		}
+	case *parse.IfNode:
+		return walkBranch(&n.BranchNode, ranging)
+	case *parse.WithNode:
+		return walkBranch(&n.BranchNode, ranging)
+	case *parse.RangeNode:
+		if ranging || !overField(n.Pipe) {
+			return ErrTemplateUnbounded
+		}
+
+		if err := walk(n.List, true); err != nil {
+			return err
+		}
+
+		return walk(n.ElseList, ranging)
+	case *parse.TemplateNode:
+		return ErrTemplateUnbounded
+	}
+
+	return nil
+}
+
+func walkBranch(n *parse.BranchNode, ranging bool) error {
+	if err := walk(n.List, ranging); err != nil {
+		return err
+	}
+
+	return walk(n.ElseList, ranging)
+}
+
+// overField reports whether a range pipeline is a plain field, as in .Labels or $.Labels.
+func overField(pipe *parse.PipeNode) bool {
+	if pipe == nil || len(pipe.Cmds) != 1 || len(pipe.Cmds[0].Args) != 1 {
+		return false
+	}
+
+	switch arg := pipe.Cmds[0].Args[0].(type) {
+	case *parse.FieldNode:
+		return true
+	case *parse.VariableNode:
+		return len(arg.Ident) > 1 && arg.Ident[0] == "$"
+	}
+
+	return false
+}
+
+// printf is fmt.Sprintf refusing widths and precisions that would pad
+// a value out to a huge string before the output limit could stop it.
+func printf(format string, args ...any) (string, error) {
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+
+		j := i + 1
+		for j < len(format) && !isVerb(format[j]) {
+			j++
+		}
+
+		if spec := format[i+1 : j]; strings.Contains(spec, "*") || longNumber.MatchString(spec) {
+			return "", ErrTemplateUnbounded
+		}
+
+		i = j
+	}
+
+	return fmt.Sprintf(format, args...), nil
+}
+
+var longNumber = regexp.MustCompile(`[0-9]{4,}`)
+
+func isVerb(c byte) bool {
+	return c == '%' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// truncate shortens escaped text to n visible runes.
+func truncate(n int, s string) string {
+	runes := []rune(html.UnescapeString(s))
+	if len(runes) <= n {
+		return s
+	}
+
+	return html.EscapeString(string(runes[:max(0, n)])) + "…"
+}
+
+func date(layout string, t time.Time) string {
+	return html.EscapeString(t.UTC().Format(layout))
+}
+
+// limitedBuffer stops a template whose output could never fit a message,
+// such as one ranging over a large number.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.limit {
+		return 0, ErrTemplateOverflow
+	}
+
+	return b.Buffer.Write(p)
+}
+
+var (
+	allowedTags = map[string]bool{"b": true, "i": true, "u": true, "s": true, "code": true, "pre": true, "a": true}
+	entity      = regexp.MustCompile(`^&(lt|gt|amp|quot|#[0-9]+|#x[0-9a-fA-F]+);`)
+)
+
+// wellFormed checks the output of a template the way Telegram parses it: only the
+// supported tags, properly nested, and no bare <, > or & in the text.
+func wellFormed(text string) error {
+	var open []string
+
+	for i := 0; i < len(text); {
+		switch text[i] {
+		case '>':
+			return ErrTemplateMalformed
+		case '&':
+			match := entity.FindString(text[i:])
+			if match == "" {
+				return ErrTemplateMalformed
+			}
+
+			i += len(match)
+
+			continue
+		case '<':
+			end := strings.IndexByte(text[i:], '>')
+			if end < 0 {
+				return ErrTemplateMalformed
+			}
+
+			tag := text[i+1 : i+end]
+			i += end + 1
+
+			closing := strings.HasPrefix(tag, "/")
+			name, attrs := tagName(strings.TrimPrefix(tag, "/"))
+
+			if !allowedTags[name] {
+				return ErrTemplateMalformed
+			}
+
+			if closing {
+				if len(open) == 0 || open[len(open)-1] != name {
+					return ErrTemplateMalformed
+				}
+
+				open = open[:len(open)-1]
+
+				continue
+			}
+
+			if name == "a" && !isWebLink(attr(attrs, "href")) {
+				return ErrTemplateMalformed
+			}
+
+			open = append(open, name)
+
+			continue
+		}
+
+		i++
+	}
+
+	if len(open) > 0 {
+		return ErrTemplateMalformed
+	}
+
+	return nil
+}
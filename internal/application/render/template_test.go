@@ -0,0 +1,158 @@
+package render_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/render"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTemplate(t *testing.T) {
+	tests := map[string]struct {
+		template    string
+		expectedErr error
+		wantErr     bool
+	}{
+		"fields and functions": {
+			template: `<b>{{.Title | truncate 10}}</b> · {{join .Labels ", "}} · {{.CreatedAt | date "02 Jan"}}`,
+		},
+		"link": {
+			template: `<a href="{{.URL}}">{{.Title}}</a>`,
+		},
+		"unknown field": {
+			template: "{{.Missing}}",
+			wantErr:  true,
+		},
+		"unknown function": {
+			template: "{{upper .Title}}",
+			wantErr:  true,
+		},
+		"empty output": {
+			template:    "{{if false}}{{.Title}}{{end}}",
+			expectedErr: render.ErrTemplateEmpty,
+		},
+		"unsupported tag": {
+			template:    "<h1>{{.Title}}</h1>",
+			expectedErr: render.ErrTemplateMalformed,
+		},
+		"misnested tags": {
+			template:    "<b><i>{{.Title}}</b></i>",
+			expectedErr: render.ErrTemplateMalformed,
+		},
+		"relative link": {
+			template:    `<a href="/issues">{{.Title}}</a>`,
+			expectedErr: render.ErrTemplateMalformed,
+		},
+		"bare ampersand": {
+			template:    "{{.Title}} & co",
+			expectedErr: render.ErrTemplateMalformed,
+		},
+		"runaway output": {
+			template:    `{{range .Labels}}{{printf "%999s%999s%999s" $.Kind $.Kind $.Kind}}{{end}}`,
+			expectedErr: render.ErrTemplateOverflow,
+		},
+		"range over a variable field": {
+			template: "{{range $.Labels}}#{{.}} {{end}}",
+		},
+		"range over an integer": {
+			template:    "{{range 2000000000}}{{end}}x",
+			expectedErr: render.ErrTemplateUnbounded,
+		},
+		"range over a function result": {
+			template:    "{{range len .Body}}x{{end}}",
+			expectedErr: render.ErrTemplateUnbounded,
+		},
+		"nested range": {
+			template:    "{{range .Labels}}{{range $.Labels}}x{{end}}{{end}}",
+			expectedErr: render.ErrTemplateUnbounded,
+		},
+		"recursive template": {
+			template:    `{{define "x"}}{{template "x" .}}{{end}}{{template "x" .}}`,
+			expectedErr: render.ErrTemplateUnbounded,
+		},
+		"huge printf width": {
+			template:    `{{printf "%999999999d" 1}}`,
+			expectedErr: render.ErrTemplateUnbounded,
+		},
+		"printf width from an argument": {
+			template:    `{{printf "%*d" 999999999 1}}`,
+			expectedErr: render.ErrTemplateUnbounded,
+		},
+		"printf": {
+			template: `{{printf "%-10s|%%1000" .Kind}}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := render.ParseTemplate(test.template)
+
+			switch {
+			case test.expectedErr != nil:
+				require.ErrorIs(t, err, test.expectedErr)
+			case test.wantErr:
+				require.Error(t, err)
+			default:
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTemplateUpdate(t *testing.T) {
+	tmpl, err := render.ParseTemplate(`{{.Kind}} <b>{{.Title}}</b> by {{.Author}}{{range .Labels}} #{{.}}{{end}}`)
+	require.NoError(t, err)
+
+	update := models.NewUpdate("1", models.KindPR, "Use a < b & c", "https://github.com/example/repo/pull/1",
+		time.Date(2025, 6, 7, 9, 0, 0, 0, time.UTC), "<script>", "", "needs-review")
+
+	message, err := tmpl.Update("https://github.com/example/repo", update)
+	require.NoError(t, err)
+	require.Equal(t, "pr <b>Use a &lt; b &amp; c</b> by &lt;script&gt; #needs-review", message)
+}
+
+func TestWithTemplate(t *testing.T) {
+	update := models.NewUpdate("1", models.KindIssue, "Crash", "https://github.com/example/repo/issues/1",
+		time.Date(2025, 6, 7, 9, 0, 0, 0, time.UTC), "gopher", "")
+	update.Source = config.GitHub
+
+	link := "https://github.com/example/repo"
+	standard := render.Update(link, update)
+
+	tests := map[string]struct {
+		template string
+		expected []string
+	}{
+		"no template": {
+			template: "",
+			expected: standard,
+		},
+		"own layout": {
+			template: "{{.Title}} {{.URL}}",
+			expected: []string{"Crash https://github.com/example/repo/issues/1"},
+		},
+		"broken template": {
+			template: "{{.Title",
+			expected: standard,
+		},
+		"fails on this update only": {
+			template: "{{index .Labels 1}}",
+			expected: standard,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, test.expected, render.WithTemplate(test.template, link, update))
+		})
+	}
+}
+
+func TestPreview(t *testing.T) {
+	preview, err := render.Preview("{{.Body}}")
+	require.NoError(t, err)
+	require.Equal(t, "The bot <b>panics</b> on start when <code>config.yaml</code> is missing.", preview)
+}
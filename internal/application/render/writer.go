@@ -0,0 +1,156 @@
+package render
+
+import (
+	"html"
+	"strings"
+)
+
+// writer builds Telegram HTML from the converted body. It escapes all text,
+// counts visible runes and stops at the limit, and closes whatever tags are
+// still open, so its output is well-formed however the input ended.
+type writer struct {
+	b      strings.Builder
+	open   []string
+	limit  int
+	length int
+	full   bool
+
+	// pending holds a line break until text follows it, which keeps
+	// runs of block elements from stacking up empty lines.
+	pending int
+	// space is owed between two runs of text separated by whitespace.
+	space bool
+}
+
+func newWriter(limit int) *writer {
+	return &writer{limit: limit}
+}
+
+func (w *writer) text(s string) {
+	if w.full || s == "" {
+		return
+	}
+
+	if w.inside("pre") {
+		w.write(s)
+		return
+	}
+
+	// Outside of pre, whitespace is collapsed as a browser would.
+	var b strings.Builder
+
+	for _, r := range s {
+		if isSpace(r) {
+			w.space = true
+			continue
+		}
+
+		// A pending line break already separates this run from the previous one.
+		if w.space && (b.Len() > 0 || w.length > 0 && w.pending == 0) {
+			b.WriteRune(' ')
+		}
+
+		w.space = false
+		b.WriteRune(r)
+	}
+
+	if b.Len() > 0 {
+		w.write(b.String())
+	}
+}
+
+func (w *writer) write(s string) {
+	if w.pending > 0 && w.length > 0 {
+		w.b.WriteString(strings.Repeat("\n", w.pending))
+		w.length += w.pending
+	}
+
+	w.pending = 0
+
+	runes := []rune(s)
+	if w.length+len(runes) > w.limit {
+		runes = runes[:max(0, w.limit-w.length)]
+		w.full = true
+	}
+
+	w.b.WriteString(html.EscapeString(string(runes)))
+	w.length += len(runes)
+
+	if w.full {
+		w.b.WriteString("…")
+	}
+}
+
+// newline asks for n line breaks before the next text.
+func (w *writer) newline(n int) {
+	w.pending = max(w.pending, n)
+	w.space = false
+}
+
+func (w *writer) openTag(tag, href string) {
+	if w.full {
+		return
+	}
+
+	if w.pending > 0 && w.length > 0 {
+		w.b.WriteString(strings.Repeat("\n", w.pending))
+		w.length += w.pending
+		w.pending = 0
+	}
+
+	if w.space && w.length > 0 {
+		w.b.WriteString(" ")
+		w.length++
+		w.space = false
+	}
+
+	if tag == "a" {
+		w.b.WriteString(`<a href="` + html.EscapeString(href) + `">`)
+	} else {
+		w.b.WriteString("<" + tag + ">")
+	}
+
+	w.open = append(w.open, tag)
+}
+
+// closeTag closes the innermost open tag of the kind together with the
+// ones opened after it, as unbalanced input leaves them behind.
+func (w *writer) closeTag(tag string) {
+	for i := len(w.open) - 1; i >= 0; i-- {
+		if w.open[i] != tag {
+			continue
+		}
+
+		for j := len(w.open) - 1; j >= i; j-- {
+			w.b.WriteString("</" + w.open[j] + ">")
+		}
+
+		w.open = w.open[:i]
+
+		return
+	}
+}
+
+func (w *writer) inside(tag string) bool {
+	for _, open := range w.open {
+		if open == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (w *writer) String() string {
+	for i := len(w.open) - 1; i >= 0; i-- {
+		w.b.WriteString("</" + w.open[i] + ">")
+	}
+
+	w.open = nil
+
+	return strings.TrimSpace(w.b.String())
+}
+
+func isSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == '\f'
+}
@@ -0,0 +1,47 @@
+package digest
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+)
+
+// DefaultTop is how many updates of a link a digest lists when no limit is configured.
+const DefaultTop = 5
+
+// Compose sums up the updates of a link, oldest first as they are stored, for a digest.
+// Kinds are counted most frequent first and the latest top updates are kept, newest first.
+func Compose(url string, updates []models.Update, top int) models.LinkDigest {
+	if top <= 0 {
+		top = DefaultTop
+	}
+
+	counts := make(map[models.Kind]int)
+	for _, update := range updates {
+		counts[update.Kind]++
+	}
+
+	kinds := make([]models.KindCount, 0, len(counts))
+	for kind, count := range counts {
+		kinds = append(kinds, models.KindCount{Kind: kind, Count: count})
+	}
+
+	slices.SortFunc(kinds, func(a, b models.KindCount) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+
+		return cmp.Compare(a.Kind, b.Kind)
+	})
+
+	latest := slices.Clone(updates[max(0, len(updates)-top):])
+	slices.Reverse(latest)
+
+	return models.LinkDigest{
+		URL:     url,
+		Total:   len(updates),
+		Counts:  kinds,
+		Updates: latest,
+	}
+}
@@ -0,0 +1,68 @@
+package digest_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/scrapper/digest"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompose(t *testing.T) {
+	at := time.Date(2025, 6, 4, 10, 0, 0, 0, time.UTC)
+	kinds := []models.Kind{models.KindIssue, models.KindPR, models.KindComment, models.KindPR, models.KindComment,
+		models.KindPR, models.KindIssue}
+
+	updates := make([]models.Update, 0, len(kinds))
+	for i, kind := range kinds {
+		updates = append(updates, models.NewUpdate(strconv.Itoa(i), kind, "", "", at.Add(time.Duration(i)*time.Minute), "", ""))
+	}
+
+	tests := map[string]struct {
+		updates     []models.Update
+		top         int
+		expectedIDs []string
+		more        int
+	}{
+		"latest first": {
+			updates:     updates,
+			top:         3,
+			expectedIDs: []string{"6", "5", "4"},
+			more:        4,
+		},
+		"everything fits": {
+			updates:     updates[:2],
+			top:         3,
+			expectedIDs: []string{"1", "0"},
+		},
+		"default limit": {
+			updates:     updates,
+			expectedIDs: []string{"6", "5", "4", "3", "2"},
+			more:        2,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			summary := digest.Compose("https://github.com/example/repo", test.updates, test.top)
+
+			ids := make([]string, 0, len(summary.Updates))
+			for _, update := range summary.Updates {
+				ids = append(ids, update.ID)
+			}
+
+			require.Equal(t, test.expectedIDs, ids)
+			require.Equal(t, len(test.updates), summary.Total)
+			require.Equal(t, test.more, summary.More())
+		})
+	}
+
+	summary := digest.Compose("https://github.com/example/repo", updates, 3)
+	require.Equal(t, []models.KindCount{
+		{Kind: models.KindPR, Count: 3},
+		{Kind: models.KindComment, Count: 2},
+		{Kind: models.KindIssue, Count: 2},
+	}, summary.Counts)
+}
@@ -0,0 +1,94 @@
+package digest
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+const clockLayout = "15:04"
+
+// Schedule tells when a chat's digest is due.
+// Every spec is evaluated in the chat's timezone, the earliest one wins.
+type Schedule struct {
+	loc   *time.Location
+	specs []cron.Schedule
+}
+
+// Parse accepts either comma-separated delivery times ("09:00,18:30")
+// or a standard five-field cron expression ("0 9 * * 1-5").
+func Parse(timezone, raw string) (*Schedule, error) {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("timezone %q: %w", timezone, ErrUnknownTimezone)
+	}
+
+	raw = strings.TrimSpace(raw)
+	if raw == "" || strings.Contains(raw, "TZ=") {
+		return nil, fmt.Errorf("schedule %q: %w", raw, ErrInvalidSchedule)
+	}
+
+	specs, ok := parseTimes(raw)
+	if !ok {
+		spec, err := cron.ParseStandard(raw)
+		if err != nil {
+			return nil, fmt.Errorf("schedule %q: %w", raw, ErrInvalidSchedule)
+		}
+
+		specs = []cron.Schedule{spec}
+	}
+
+	return &Schedule{
+		loc:   loc,
+		specs: specs,
+	}, nil
+}
+
+func Validate(timezone, raw string) error {
+	_, err := Parse(timezone, raw)
+	return err
+}
+
+// Next returns the first delivery moment strictly after the given one.
+func (s *Schedule) Next(after time.Time) time.Time {
+	local := after.In(s.loc)
+
+	var next time.Time
+
+	for _, spec := range s.specs {
+		candidate := spec.Next(local)
+		if next.IsZero() || candidate.Before(next) {
+			next = candidate
+		}
+	}
+
+	return next
+}
+
+// Due reports whether a delivery moment has passed since the last digest was sent.
+func (s *Schedule) Due(sentAt, now time.Time) bool {
+	return !s.Next(sentAt).After(now)
+}
+
+func parseTimes(raw string) ([]cron.Schedule, bool) {
+	parts := strings.Split(raw, ",")
+	specs := make([]cron.Schedule, 0, len(parts))
+
+	for _, part := range parts {
+		at, err := time.Parse(clockLayout, strings.TrimSpace(part))
+		if err != nil {
+			return nil, false
+		}
+
+		spec, err := cron.ParseStandard(fmt.Sprintf("%d %d * * *", at.Minute(), at.Hour()))
+		if err != nil {
+			return nil, false
+		}
+
+		specs = append(specs, spec)
+	}
+
+	return specs, true
+}
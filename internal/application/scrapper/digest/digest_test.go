@@ -0,0 +1,107 @@
+package digest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/scrapper/digest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		timezone string
+		schedule string
+		wantErr  error
+	}{
+		"single time": {
+			timezone: "UTC",
+			schedule: "10:00",
+		},
+		"several times": {
+			timezone: "Europe/Moscow",
+			schedule: "09:00, 18:30",
+		},
+		"cron expression": {
+			timezone: "America/New_York",
+			schedule: "0 9 * * 1-5",
+		},
+		"unknown timezone": {
+			timezone: "Mars/Olympus",
+			schedule: "10:00",
+			wantErr:  digest.ErrUnknownTimezone,
+		},
+		"empty schedule": {
+			timezone: "UTC",
+			schedule: " ",
+			wantErr:  digest.ErrInvalidSchedule,
+		},
+		"malformed time": {
+			timezone: "UTC",
+			schedule: "25:00",
+			wantErr:  digest.ErrInvalidSchedule,
+		},
+		"timezone inside cron": {
+			timezone: "UTC",
+			schedule: "CRON_TZ=Asia/Tokyo 0 9 * * *",
+			wantErr:  digest.ErrInvalidSchedule,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := digest.Parse(test.timezone, test.schedule)
+			require.ErrorIs(t, err, test.wantErr)
+		})
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	after := time.Date(2025, 6, 4, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		timezone string
+		schedule string
+		expected time.Time
+	}{
+		"later today in utc": {
+			timezone: "UTC",
+			schedule: "10:00,18:30",
+			expected: time.Date(2025, 6, 4, 18, 30, 0, 0, time.UTC),
+		},
+		"tomorrow in utc": {
+			timezone: "UTC",
+			schedule: "10:00",
+			expected: time.Date(2025, 6, 5, 10, 0, 0, 0, time.UTC),
+		},
+		"local time of the chat": {
+			timezone: "Asia/Tokyo",
+			schedule: "22:00",
+			expected: time.Date(2025, 6, 4, 13, 0, 0, 0, time.UTC),
+		},
+		"cron expression on weekdays": {
+			timezone: "UTC",
+			schedule: "0 9 * * 1-5",
+			expected: time.Date(2025, 6, 5, 9, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			schedule, err := digest.Parse(test.timezone, test.schedule)
+			require.NoError(t, err)
+
+			require.True(t, test.expected.Equal(schedule.Next(after)), schedule.Next(after).UTC().String())
+		})
+	}
+}
+
+func TestScheduleDue(t *testing.T) {
+	schedule, err := digest.Parse("Europe/Berlin", "09:00")
+	require.NoError(t, err)
+
+	sentAt := time.Date(2025, 6, 3, 7, 0, 0, 0, time.UTC)
+
+	require.False(t, schedule.Due(sentAt, time.Date(2025, 6, 4, 6, 59, 0, 0, time.UTC)))
+	require.True(t, schedule.Due(sentAt, time.Date(2025, 6, 4, 7, 0, 0, 0, time.UTC)))
+}
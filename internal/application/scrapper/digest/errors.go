@@ -0,0 +1,12 @@
+package digest
+
+import "fmt"
+
+type digestError struct{ msg string }
+
+func (e digestError) Error() string { return fmt.Sprintf("error: %s", e.msg) }
+
+var (
+	ErrUnknownTimezone = digestError{msg: "unknown timezone"}
+	ErrInvalidSchedule = digestError{msg: "schedule is neither a list of HH:MM times nor a cron expression"}
+)
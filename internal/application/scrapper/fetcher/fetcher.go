@@ -2,8 +2,10 @@ package fetcher
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/es-debug/backend-academy-2024-go-template/config"
@@ -13,19 +15,36 @@ import (
 )
 
 type ExternalClient interface {
-	RetrieveUpdates(ctx context.Context, link string) ([]models.Update, error)
+	RetrieveScoredUpdates(
+		ctx context.Context,
+		link string,
+		since time.Time,
+		score *int64,
+	) ([]models.Update, *int64, error)
 }
 
 type Storage interface {
-	GetLinks(ctx context.Context, batch uint64) ([]sapi.LinkResponse, error)
+	LeaseLinks(ctx context.Context, batch uint64, ttl time.Duration) ([]sapi.LinkResponse, error)
+	GetLinkCursor(ctx context.Context, linkID int64) (models.Cursor, error)
+	SaveUpdates(ctx context.Context, linkID int64, updates []models.Update) (int, error)
 	TouchLink(ctx context.Context, linkID int64) error
+	MarkLinkChecked(ctx context.Context, linkID int64, result models.CheckResult) error
+	GetLinkSchedule(ctx context.Context, linkID int64) (models.Schedule, error)
 	UpdateLinkActivity(ctx context.Context, linkID int64, status bool) error
+	GetInstantSubscribers(ctx context.Context, linkID int64) ([]models.Subscriber, error)
+	GetSubscriptionCursor(ctx context.Context, chatID, linkID int64) (models.Cursor, error)
+	GetPendingUpdates(ctx context.Context, linkID int64, cursor models.Cursor) ([]models.Update, error)
+	UpdateSubscriptionCursor(ctx context.Context, chatID, linkID int64, cursor models.Cursor) error
+}
+
+type UpdateSender interface {
+	Send(ctx context.Context, chatID int64, url string, update models.Update) error
 }
 
 type Fetcher struct {
 	Storage Storage
-	GitHub  ExternalClient
-	Stack   ExternalClient
+	Client  ExternalClient
+	Sender  UpdateSender
 	Sch     gocron.Scheduler
 	Sem     chan struct{}
 	Cfg     *config.Updater
@@ -33,15 +52,15 @@ type Fetcher struct {
 
 func New(
 	storage Storage,
-	github ExternalClient,
-	stack ExternalClient,
+	client ExternalClient,
+	sender UpdateSender,
 	sch gocron.Scheduler,
 	cfg *config.Updater,
 ) *Fetcher {
 	return &Fetcher{
 		Storage: storage,
-		GitHub:  github,
-		Stack:   stack,
+		Client:  client,
+		Sender:  sender,
 		Sch:     sch,
 		Sem:     make(chan struct{}, cfg.NumWorkers),
 		Cfg:     cfg,
@@ -54,7 +73,7 @@ func (f *Fetcher) Run(ctx context.Context) error {
 
 func (f *Fetcher) ScrapeLinks(ctx context.Context) error {
 	for {
-		links, err := f.Storage.GetLinks(ctx, f.Cfg.BatchSize)
+		links, err := f.Storage.LeaseLinks(ctx, f.Cfg.BatchSize, f.Cfg.LeaseDuration)
 		if err != nil {
 			slog.Warn(
 				"updater: failed to get links",
@@ -62,12 +81,17 @@ func (f *Fetcher) ScrapeLinks(ctx context.Context) error {
 			)
 		}
 
+		var wg sync.WaitGroup
+
 		for _, link := range links {
 			select {
 			case <-ctx.Done():
 				return context.Cause(ctx)
 			case f.Sem <- struct{}{}:
+				wg.Add(1)
+
 				go func() {
+					defer wg.Done()
 					defer func() { <-f.Sem }()
 
 					if err := f.ProcessLink(ctx, link); err != nil {
@@ -80,31 +104,83 @@ func (f *Fetcher) ScrapeLinks(ctx context.Context) error {
 			}
 		}
 
+		// A pass is finished only once every link in it is rescheduled,
+		// otherwise the next batch could pick the same links again.
+		wg.Wait()
+
+		// A full batch means more links are overdue, so keep draining the backlog.
+		if uint64(len(links)) == f.Cfg.BatchSize {
+			continue
+		}
+
 		select {
 		case <-ctx.Done():
 			return context.Cause(ctx)
-		case <-time.After(5 * time.Minute):
+		case <-time.After(f.Cfg.PollInterval):
 		}
 	}
 }
 
 func (f *Fetcher) ProcessLink(ctx context.Context, link sapi.LinkResponse) error {
-	updated, err := f.CheckActivity(ctx, link.Url)
+	schedule, err := f.Storage.GetLinkSchedule(ctx, link.Id)
+	if err != nil {
+		return fmt.Errorf("failed to get link schedule: %w", err)
+	}
+
+	saved, score, checkErr := f.CheckLink(ctx, link, schedule.Score)
+
+	result := models.NewCheckResult(saved, checkErr)
+	result.Score = score
+	result.Interval = Backoff(f.Cfg, schedule.Interval, result.Active())
+	result.NextCheckAt = time.Now().Add(Weigh(f.Cfg, result.Interval, schedule.Subscribers))
+
+	if err := f.Storage.MarkLinkChecked(ctx, link.Id, result); err != nil {
+		return errors.Join(checkErr, fmt.Errorf("failed to mark link checked: %w", err))
+	}
+
+	if saved > 0 {
+		if err := f.NotifyInstant(ctx, link); err != nil {
+			return errors.Join(checkErr, fmt.Errorf("failed to deliver instant updates: %w", err))
+		}
+	}
+
+	return checkErr
+}
+
+// CheckLink stores the updates published since the link was last seen
+// and reports how many of them are new, along with the score the link has now.
+// The score is returned only once the updates it announces are stored.
+func (f *Fetcher) CheckLink(ctx context.Context, link sapi.LinkResponse, score *int64) (int, *int64, error) {
+	cursor, err := f.Storage.GetLinkCursor(ctx, link.Id)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to get link cursor: %w", err)
+	}
+
+	updates, score, err := f.FetchUpdates(ctx, link.Url, cursor, score)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to fetch updates: %w", err)
+	}
+
+	if len(updates) == 0 {
+		return 0, score, nil
+	}
+
+	saved, err := f.Storage.SaveUpdates(ctx, link.Id, updates)
 	if err != nil {
-		return fmt.Errorf("failed to check activity: %w", err)
+		return 0, nil, fmt.Errorf("failed to save updates: %w", err)
 	}
 
-	if !updated {
-		return nil
+	if saved == 0 {
+		return 0, score, nil
 	}
 
-	if err := f.Storage.UpdateLinkActivity(ctx, link.Id, updated); err != nil {
-		return fmt.Errorf("failed to update link activity: %w", err)
+	if err := f.Storage.UpdateLinkActivity(ctx, link.Id, true); err != nil {
+		return saved, score, fmt.Errorf("failed to update link activity: %w", err)
 	}
 
 	if err := f.Storage.TouchLink(ctx, link.Id); err != nil {
-		return fmt.Errorf("failed to touch link: %w", err)
+		return saved, score, fmt.Errorf("failed to touch link: %w", err)
 	}
 
-	return nil
+	return saved, score, nil
 }
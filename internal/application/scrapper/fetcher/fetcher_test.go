@@ -2,6 +2,7 @@ package fetcher_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -25,37 +26,190 @@ func TestProcessLink(t *testing.T) {
 
 	link := sapi.LinkResponse{Id: 1, Url: "https://github.com/example/repo"}
 
+	storage.On("GetLinkSchedule", mock.Anything, int64(1)).
+		Return(models.Schedule{Interval: time.Hour, Subscribers: 1}, nil)
+	storage.On("GetLinkCursor", mock.Anything, int64(1)).
+		Return(models.NewCursor(time.Now().Add(-time.Hour), ""), nil)
+	storage.On("SaveUpdates", mock.Anything, int64(1), mock.AnythingOfType("[]models.Update")).Return(1, nil)
 	storage.On("TouchLink", mock.Anything, int64(1)).Return(nil)
+	storage.On("MarkLinkChecked", mock.Anything, int64(1),
+		mock.MatchedBy(func(result models.CheckResult) bool {
+			return result.Status == models.CheckUpdated && result.Interval == 5*time.Minute
+		})).
+		Return(nil)
 	storage.On("UpdateLinkActivity", mock.Anything, int64(1), true).Return(nil)
 
-	client.On("RetrieveUpdates", mock.Anything, "https://github.com/example/repo").
-		Return([]models.Update{{CreatedAt: time.Now().Format(time.RFC3339)}}, nil)
+	client.On("RetrieveScoredUpdates", mock.Anything, "https://github.com/example/repo", mock.AnythingOfType("time.Time"),
+		(*int64)(nil)).
+		Return([]models.Update{{ID: "1", CreatedAt: time.Now()}}, nil, nil)
+
+	storage.On("GetInstantSubscribers", mock.Anything, int64(1)).Return([]models.Subscriber{}, nil)
+
+	upd := &fetcher.Fetcher{
+		Storage: storage,
+		Client:  client,
+		Cfg: &config.Updater{
+			BatchSize:     200,
+			NumWorkers:    16,
+			MinInterval:   5 * time.Minute,
+			MaxInterval:   6 * time.Hour,
+			BackoffFactor: 2,
+		},
+	}
+
+	require.NoError(t, upd.ProcessLink(ctx, link))
+}
+
+func TestProcessLinkFailure(t *testing.T) {
+	storage := mocks.NewMockUpdaterStorage(t)
+	defer storage.AssertExpectations(t)
+
+	client := mocks.NewMockExternalClient(t)
+	defer client.AssertExpectations(t)
+
+	ctx := context.Background()
+
+	link := sapi.LinkResponse{Id: 1, Url: "https://github.com/example/repo"}
+
+	storage.On("GetLinkSchedule", mock.Anything, int64(1)).
+		Return(models.Schedule{Interval: time.Hour, Subscribers: 1}, nil)
+	storage.On("GetLinkCursor", mock.Anything, int64(1)).
+		Return(models.NewCursor(time.Now().Add(-time.Hour), ""), nil)
+	storage.On("MarkLinkChecked", mock.Anything, int64(1),
+		mock.MatchedBy(func(result models.CheckResult) bool {
+			return result.Status == models.CheckFailed && result.Error != "" && result.Interval == 2*time.Hour
+		})).
+		Return(nil)
+
+	client.On("RetrieveScoredUpdates", mock.Anything, "https://github.com/example/repo", mock.AnythingOfType("time.Time"),
+		(*int64)(nil)).
+		Return(nil, nil, errors.New("rate limited"))
+
+	upd := &fetcher.Fetcher{
+		Storage: storage,
+		Client:  client,
+		Cfg: &config.Updater{
+			BatchSize:     200,
+			NumWorkers:    16,
+			MinInterval:   5 * time.Minute,
+			MaxInterval:   6 * time.Hour,
+			BackoffFactor: 2,
+		},
+	}
+
+	require.Error(t, upd.ProcessLink(ctx, link))
+}
+
+func TestProcessLinkKeepsScore(t *testing.T) {
+	storage := mocks.NewMockUpdaterStorage(t)
+	defer storage.AssertExpectations(t)
+
+	client := mocks.NewMockExternalClient(t)
+	defer client.AssertExpectations(t)
+
+	ctx := context.Background()
+
+	link := sapi.LinkResponse{Id: 1, Url: "https://stackoverflow.com/questions/1"}
+	previous, current := int64(8), int64(27)
+
+	storage.On("GetLinkSchedule", mock.Anything, int64(1)).
+		Return(models.Schedule{Interval: time.Hour, Subscribers: 1, Score: &previous}, nil)
+	storage.On("GetLinkCursor", mock.Anything, int64(1)).
+		Return(models.NewCursor(time.Now().Add(-time.Hour), ""), nil)
+	storage.On("MarkLinkChecked", mock.Anything, int64(1),
+		mock.MatchedBy(func(result models.CheckResult) bool {
+			return result.Status == models.CheckIdle && result.Score != nil && *result.Score == current
+		})).
+		Return(nil)
+
+	client.On("RetrieveScoredUpdates", mock.Anything, link.Url, mock.AnythingOfType("time.Time"), &previous).
+		Return([]models.Update{}, &current, nil)
 
 	upd := &fetcher.Fetcher{
 		Storage: storage,
-		GitHub:  client,
+		Client:  client,
 		Cfg: &config.Updater{
-			BatchSize:  200,
-			NumWorkers: 16,
+			MinInterval:   5 * time.Minute,
+			MaxInterval:   6 * time.Hour,
+			BackoffFactor: 2,
 		},
 	}
 
 	require.NoError(t, upd.ProcessLink(ctx, link))
 }
 
-func TestCheckActivity(t *testing.T) {
+func TestFetchUpdates(t *testing.T) {
 	client := mocks.NewMockExternalClient(t)
 	defer client.AssertExpectations(t)
 	ctx := context.Background()
 
 	upd := &fetcher.Fetcher{
-		GitHub: client,
+		Client: client,
 	}
 
-	client.On("RetrieveUpdates", mock.Anything, "https://github.com/example/repo").
-		Return([]models.Update{{CreatedAt: time.Now().Format(time.RFC3339)}}, nil)
+	client.On("RetrieveScoredUpdates", mock.Anything, "https://github.com/example/repo", mock.AnythingOfType("time.Time"),
+		(*int64)(nil)).
+		Return([]models.Update{{ID: "1", CreatedAt: time.Now()}}, nil, nil)
+
+	cursor := models.NewCursor(time.Now().Add(-time.Hour), "")
 
-	active, err := upd.CheckActivity(ctx, "https://github.com/example/repo")
+	updates, _, err := upd.FetchUpdates(ctx, "https://github.com/example/repo", cursor, nil)
 	require.NoError(t, err)
-	require.True(t, active)
+	require.Len(t, updates, 1)
+}
+
+func TestFetchUpdatesSeen(t *testing.T) {
+	client := mocks.NewMockExternalClient(t)
+	defer client.AssertExpectations(t)
+	ctx := context.Background()
+
+	upd := &fetcher.Fetcher{
+		Client: client,
+	}
+
+	at := time.Now().Truncate(time.Second)
+	cursor := models.NewCursor(at, "1")
+
+	client.On("RetrieveScoredUpdates", mock.Anything, "https://github.com/example/repo", cursor.At, (*int64)(nil)).
+		Return([]models.Update{{ID: "1", CreatedAt: at}}, nil, nil)
+
+	updates, _, err := upd.FetchUpdates(ctx, "https://github.com/example/repo", cursor, nil)
+	require.NoError(t, err)
+	require.Empty(t, updates)
+}
+
+func TestNotifyInstant(t *testing.T) {
+	storage := mocks.NewMockUpdaterStorage(t)
+	defer storage.AssertExpectations(t)
+
+	sender := mocks.NewMockUpdateSender(t)
+	defer sender.AssertExpectations(t)
+
+	ctx := context.Background()
+
+	link := sapi.LinkResponse{Id: 1, Url: "https://github.com/example/repo"}
+	cursor := models.NewCursor(time.Now().Add(-time.Hour), "")
+	at := time.Now()
+
+	storage.On("GetInstantSubscribers", mock.Anything, int64(1)).
+		Return([]models.Subscriber{models.NewSubscriber(7, "-user:dependabot")}, nil)
+	storage.On("GetSubscriptionCursor", mock.Anything, int64(7), int64(1)).Return(cursor, nil)
+	storage.On("GetPendingUpdates", mock.Anything, int64(1), cursor).
+		Return([]models.Update{
+			models.NewUpdate("1", models.KindPR, "bump deps", "", at, "dependabot", ""),
+			models.NewUpdate("2", models.KindIssue, "crash", "", at, "gopher", ""),
+		}, nil)
+	storage.On("UpdateSubscriptionCursor", mock.Anything, int64(7), int64(1),
+		mock.MatchedBy(func(next models.Cursor) bool { return next.ID == "2" })).
+		Return(nil)
+
+	sender.On("Send", mock.Anything, int64(7), link.Url, mock.AnythingOfType("models.Update")).
+		Once().Return(nil)
+
+	upd := &fetcher.Fetcher{
+		Storage: storage,
+		Sender:  sender,
+	}
+
+	require.NoError(t, upd.NotifyInstant(ctx, link))
 }
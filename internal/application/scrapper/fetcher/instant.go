@@ -0,0 +1,68 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	sapi "github.com/es-debug/backend-academy-2024-go-template/internal/api/openapi/v1/servers/scrapper"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/filter"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+)
+
+// NotifyInstant fans freshly saved updates of a shared link out to the chats subscribed in instant mode.
+// A chat whose delivery fails keeps its cursor, so the scheduled digest picks the updates up later.
+func (f *Fetcher) NotifyInstant(ctx context.Context, link sapi.LinkResponse) error {
+	subscribers, err := f.Storage.GetInstantSubscribers(ctx, link.Id)
+	if err != nil {
+		return fmt.Errorf("failed to get instant subscribers: %w", err)
+	}
+
+	var errs []error
+
+	for _, subscriber := range subscribers {
+		if err := f.Deliver(ctx, subscriber, link); err != nil {
+			errs = append(errs, fmt.Errorf("chat %d: %w", subscriber.ChatID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (f *Fetcher) Deliver(ctx context.Context, subscriber models.Subscriber, link sapi.LinkResponse) error {
+	chatID := subscriber.ChatID
+
+	sieve, skipped := filter.ParseStored(subscriber.Filters)
+	for _, err := range skipped {
+		slog.Warn("fetcher: ignoring stored filter",
+			slog.Int64("chat_id", chatID),
+			slog.Int64("link_id", link.Id),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	cursor, err := f.Storage.GetSubscriptionCursor(ctx, chatID, link.Id)
+	if err != nil {
+		return err
+	}
+
+	pending, err := f.Storage.GetPendingUpdates(ctx, link.Id, cursor)
+	if err != nil {
+		return err
+	}
+
+	fresh, next := cursor.Sieve(pending)
+
+	for _, update := range sieve.Apply(fresh) {
+		if err = f.Sender.Send(ctx, chatID, link.Url, update); err != nil {
+			return err
+		}
+	}
+
+	if next.At.Equal(cursor.At) && next.ID == cursor.ID {
+		return nil
+	}
+
+	return f.Storage.UpdateSubscriptionCursor(ctx, chatID, link.Id, next)
+}
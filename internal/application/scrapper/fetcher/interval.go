@@ -0,0 +1,36 @@
+package fetcher
+
+import (
+	"math"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/config"
+)
+
+// Backoff returns the base poll interval of a link after a check:
+// active links drop to the minimum, quiet ones back off exponentially up to the maximum.
+func Backoff(cfg *config.Updater, prev time.Duration, active bool) time.Duration {
+	if active || prev <= 0 {
+		return cfg.MinInterval
+	}
+
+	next := time.Duration(float64(prev) * cfg.BackoffFactor)
+
+	return clampInterval(cfg, next)
+}
+
+// Weigh shortens the base interval for links many chats subscribe to,
+// so that a popular link is polled more often than a personal one.
+func Weigh(cfg *config.Updater, interval time.Duration, subscribers int) time.Duration {
+	if subscribers < 1 {
+		subscribers = 1
+	}
+
+	weight := 1 + math.Log2(float64(subscribers))
+
+	return clampInterval(cfg, time.Duration(float64(interval)/weight))
+}
+
+func clampInterval(cfg *config.Updater, interval time.Duration) time.Duration {
+	return min(max(interval, cfg.MinInterval), cfg.MaxInterval)
+}
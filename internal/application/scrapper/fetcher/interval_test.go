@@ -0,0 +1,88 @@
+package fetcher_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/scrapper/fetcher"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackoff(t *testing.T) {
+	cfg := &config.Updater{
+		MinInterval:   time.Minute,
+		MaxInterval:   time.Hour,
+		BackoffFactor: 2,
+	}
+
+	tests := map[string]struct {
+		prev     time.Duration
+		active   bool
+		expected time.Duration
+	}{
+		"first check": {
+			prev:     0,
+			expected: time.Minute,
+		},
+		"active link resets": {
+			prev:     40 * time.Minute,
+			active:   true,
+			expected: time.Minute,
+		},
+		"quiet link backs off": {
+			prev:     4 * time.Minute,
+			expected: 8 * time.Minute,
+		},
+		"capped at maximum": {
+			prev:     40 * time.Minute,
+			expected: time.Hour,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, test.expected, fetcher.Backoff(cfg, test.prev, test.active))
+		})
+	}
+}
+
+func TestWeigh(t *testing.T) {
+	cfg := &config.Updater{
+		MinInterval: time.Minute,
+		MaxInterval: time.Hour,
+	}
+
+	tests := map[string]struct {
+		interval    time.Duration
+		subscribers int
+		expected    time.Duration
+	}{
+		"single subscriber": {
+			interval:    30 * time.Minute,
+			subscribers: 1,
+			expected:    30 * time.Minute,
+		},
+		"no subscribers": {
+			interval:    30 * time.Minute,
+			subscribers: 0,
+			expected:    30 * time.Minute,
+		},
+		"popular link": {
+			interval:    30 * time.Minute,
+			subscribers: 8,
+			expected:    30 * time.Minute / 4,
+		},
+		"never below minimum": {
+			interval:    2 * time.Minute,
+			subscribers: 1024,
+			expected:    time.Minute,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, test.expected, fetcher.Weigh(cfg, test.interval, test.subscribers))
+		})
+	}
+}
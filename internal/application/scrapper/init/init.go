@@ -1,6 +1,7 @@
 package scrapperinit
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"hash/adler32"
@@ -17,8 +18,9 @@ import (
 	ss "github.com/es-debug/backend-academy-2024-go-template/internal/application/scrapper/service"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/application/scrapper/storage"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/application/scrapper/updater"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/sources"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
-	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/clients"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/coordination"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/producers"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/repository/chats"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/repository/db"
@@ -27,10 +29,15 @@ import (
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/repository/subs"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/repository/tags"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/repository/txs"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/repository/updates"
 	scrapperserver "github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/servers/scrapper"
+	_ "github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/sources/all"
+	"github.com/es-debug/backend-academy-2024-go-template/pkg/safedial"
 	"github.com/go-co-op/gocron/v2"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
 	"github.com/segmentio/kafka-go"
+	"go.uber.org/fx"
 )
 
 func Config() (*config.Config, error) {
@@ -43,6 +50,10 @@ func Config() (*config.Config, error) {
 		return nil, err
 	}
 
+	if err := sources.Configure(cfg); err != nil {
+		return nil, err
+	}
+
 	return cfg, nil
 }
 
@@ -75,6 +86,10 @@ func FiltersRepository(cfg *config.Config, pool *pgxpool.Pool) filters.Repositor
 	return filters.New(cfg.Database, pool)
 }
 
+func UpdatesRepository(cfg *config.Config, pool *pgxpool.Pool) updates.Repository {
+	return updates.New(cfg.Database, pool)
+}
+
 func Transactor(pool *pgxpool.Pool) *txs.TxBeginner {
 	return txs.New(pool)
 }
@@ -85,9 +100,10 @@ func Storage(
 	subs subs.Repository,
 	tags tags.Repository,
 	filters filters.Repository,
+	updates updates.Repository,
 	tx *txs.TxBeginner,
 ) *storage.Storage {
-	return storage.New(chats, links, subs, tags, filters, tx)
+	return storage.New(chats, links, subs, tags, filters, updates, tx)
 }
 
 func BotClient(cfg *config.Config) (botclient.ClientInterface, error) {
@@ -104,16 +120,33 @@ func BotClient(cfg *config.Config) (botclient.ClientInterface, error) {
 	return client, nil
 }
 
-func StackClient(cfg *config.Config) clients.Client {
-	return clients.New(config.StackOverflow, cfg)
-}
-
-func GitHubClient(cfg *config.Config) clients.Client {
-	return clients.New(config.GitHub, cfg)
-}
+func SourceClients(cfg *config.Config) *sources.Clients {
+	return sources.NewClients(cfg)
+}
+
+func Scheduler(lc fx.Lifecycle, cfg *config.Config, pool *pgxpool.Pool) (gocron.Scheduler, error) {
+	var options []gocron.SchedulerOption
+
+	switch cfg.Scheduler.Coordination {
+	case config.CoordinationRedis:
+		rdb := redis.NewClient(&redis.Options{
+			Addr: net.JoinHostPort(cfg.Cache.Host, cfg.Cache.Port),
+		})
+		lc.Append(fx.Hook{
+			OnStop: func(context.Context) error {
+				return rdb.Close()
+			},
+		})
+		options = append(options, gocron.WithDistributedLocker(
+			coordination.NewRedisLocker(rdb, cfg.Scheduler.LockTTL),
+		))
+	case config.CoordinationPostgres:
+		options = append(options, gocron.WithDistributedElector(
+			coordination.NewPostgresElector(pool, cfg.Scheduler.ElectionKey),
+		))
+	}
 
-func Scheduler() (gocron.Scheduler, error) {
-	scheduler, err := gocron.NewScheduler()
+	scheduler, err := gocron.NewScheduler(options...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create scheduler: %w", err)
 	}
@@ -142,23 +175,21 @@ func Limiter(cfg *config.Config) *limiter.Limiter {
 
 func Notifier(
 	storage *storage.Storage,
-	github clients.Client,
-	stack clients.Client,
-	updatePublisher *producers.UpdatePublisher,
+	updater *updater.Updater,
 	sch gocron.Scheduler,
 	cfg *config.Config,
 ) *notifier.Notifier {
-	return notifier.New(storage, github, stack, updatePublisher, sch, &cfg.Notifier)
+	return notifier.New(storage, updater, sch, &cfg.Notifier)
 }
 
 func Fetcher(
 	storage *storage.Storage,
-	github clients.Client,
-	stack clients.Client,
+	clients *sources.Clients,
+	updater *updater.Updater,
 	sch gocron.Scheduler,
 	cfg *config.Config,
 ) *fetcher.Fetcher {
-	return fetcher.New(storage, github, stack, sch, &cfg.Updater)
+	return fetcher.New(storage, clients, updater, sch, &cfg.Updater)
 }
 
 func KafkaUpdateWriter(cfg *config.Config) *kafka.Writer {
@@ -193,7 +224,12 @@ func ScrapperServer(
 	storage *storage.Storage,
 	lmt *limiter.Limiter,
 ) *http.Server {
-	api := sapi.New(storage)
+	guarded := &http.Client{Timeout: cfg.TimeoutPolicy.ClientOverall}
+	if !cfg.Feeds.AllowPrivateHosts {
+		guarded.Transport = safedial.Transport()
+	}
+
+	api := sapi.New(storage, guarded)
 	return scrapperserver.New(cfg, api, lmt)
 }
 
@@ -2,38 +2,51 @@ package notifier
 
 import (
 	"context"
-	"fmt"
+	"log/slog"
 
-	"github.com/es-debug/backend-academy-2024-go-template/config"
+	sapi "github.com/es-debug/backend-academy-2024-go-template/internal/api/openapi/v1/servers/scrapper"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/filter"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/scrapper/digest"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
-	"github.com/es-debug/backend-academy-2024-go-template/pkg/svcident"
 )
 
-func (n *Notifier) Notify(ctx context.Context, chatID int64, link string) error {
-	var (
-		updates []models.Update
-		err     error
-	)
+// batch is what a subscription adds to the digest of its chat,
+// with the cursor marking its updates delivered.
+type batch struct {
+	linkID int64
+	digest models.LinkDigest
+	cursor models.Cursor
+	moved  bool
+}
 
-	service, err := svcident.FromLink(link)
-	if err != nil {
-		return err
+// collect sums up the stored updates the subscription has not seen yet.
+// Updates dropped by its filters still move the cursor past them.
+func (n *Notifier) collect(ctx context.Context, chatID int64, link sapi.LinkResponse) (batch, error) {
+	sieve, skipped := filter.ParseStored(link.Filters)
+	for _, err := range skipped {
+		slog.Warn("notifier: ignoring stored filter",
+			slog.Int64("chat_id", chatID),
+			slog.Int64("link_id", link.Id),
+			slog.String("error", err.Error()),
+		)
 	}
 
-	switch service {
-	case config.GitHub:
-		updates, err = n.GitHub.RetrieveUpdates(ctx, link)
-	case config.StackOverflow:
-		updates, err = n.Stack.RetrieveUpdates(ctx, link)
-	default:
-		return fmt.Errorf("unsupported service: %s", service)
+	cursor, err := n.Storage.GetSubscriptionCursor(ctx, chatID, link.Id)
+	if err != nil {
+		return batch{}, err
 	}
 
-	for _, update := range updates {
-		if err = n.Sender.Send(ctx, chatID, link, update.String()); err != nil {
-			return err
-		}
+	pending, err := n.Storage.GetPendingUpdates(ctx, link.Id, cursor)
+	if err != nil {
+		return batch{}, err
 	}
 
-	return nil
+	fresh, next := cursor.Sieve(pending)
+
+	return batch{
+		linkID: link.Id,
+		digest: digest.Compose(link.Url, sieve.Apply(fresh), n.Top),
+		cursor: next,
+		moved:  !next.At.Equal(cursor.At) || next.ID != cursor.ID,
+	}, nil
 }
@@ -4,67 +4,60 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/es-debug/backend-academy-2024-go-template/config"
 	sapi "github.com/es-debug/backend-academy-2024-go-template/internal/api/openapi/v1/servers/scrapper"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/scrapper/digest"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
 	"github.com/go-co-op/gocron/v2"
 )
 
-type ExternalClient interface {
-	RetrieveUpdates(ctx context.Context, link string) ([]models.Update, error)
-}
-
 type Storage interface {
-	GetChatIDs(ctx context.Context) ([]int64, error)
-	GetLinksWithChatActive(ctx context.Context, chatID int64) ([]sapi.LinkResponse, error)
-	UpdateLinkActivity(ctx context.Context, linkID int64, status bool) error
+	GetChatDigests(ctx context.Context) ([]models.Digest, error)
+	ClaimDigest(ctx context.Context, chatID int64, prev, at time.Time) (bool, error)
+	GetLinksWithChatPending(ctx context.Context, chatID int64) ([]sapi.LinkResponse, error)
+	GetSubscriptionCursor(ctx context.Context, chatID, linkID int64) (models.Cursor, error)
+	GetPendingUpdates(ctx context.Context, linkID int64, cursor models.Cursor) ([]models.Update, error)
+	UpdateSubscriptionCursor(ctx context.Context, chatID, linkID int64, cursor models.Cursor) error
 }
 
 type UpdateSender interface {
-	Send(ctx context.Context, chatID int64, url, description string) error
+	SendDigest(ctx context.Context, digest models.ChatDigest) error
 }
 
 type Notifier struct {
 	Storage Storage
-	GitHub  ExternalClient
-	Stack   ExternalClient
 	Sender  UpdateSender
 	Sch     gocron.Scheduler
 	Sem     chan struct{}
+	Top     int
 }
 
 func New(
 	storage Storage,
-	github ExternalClient,
-	stack ExternalClient,
 	sender UpdateSender,
 	sch gocron.Scheduler,
 	cfg *config.Notifier,
 ) *Notifier {
 	return &Notifier{
 		Storage: storage,
-		GitHub:  github,
-		Stack:   stack,
 		Sender:  sender,
 		Sch:     sch,
 		Sem:     make(chan struct{}, cfg.NumWorkers),
+		Top:     cfg.DigestTop,
 	}
 }
 
 func (n *Notifier) Run(ctx context.Context) error {
 	_, err := n.Sch.NewJob(
-		gocron.DailyJob(
-			1,
-			gocron.NewAtTimes(
-				gocron.NewAtTime(10, 0, 0),
-			),
-		),
+		gocron.CronJob(everyMinute, false),
 		gocron.NewTask(
 			func() {
-				n.PushUpdates(ctx)
+				n.PushUpdates(ctx, time.Now())
 			},
 		),
+		gocron.WithName("notifier.digest"),
 	)
 
 	if err != nil {
@@ -76,16 +69,47 @@ func (n *Notifier) Run(ctx context.Context) error {
 	return nil
 }
 
-func (n *Notifier) PushUpdates(ctx context.Context) {
-	chatIDs, err := n.Storage.GetChatIDs(ctx)
+// PushUpdates delivers digests of the chats whose local delivery time has come since their last digest.
+// A chat is marked before its updates are sent, so a slow delivery is never started twice;
+// whatever fails to go out stays pending until the next slot.
+func (n *Notifier) PushUpdates(ctx context.Context, now time.Time) {
+	digests, err := n.Storage.GetChatDigests(ctx)
 	if err != nil {
 		slog.Error(
-			"notifier: failed to get chat ids",
+			"notifier: failed to get chat digests",
 			slog.String("msg", err.Error()),
 		)
 	}
 
-	for _, chatID := range chatIDs {
+	for _, chat := range digests {
+		schedule, err := digest.Parse(chat.Timezone, chat.Schedule)
+		if err != nil {
+			slog.Error("notifier: invalid digest schedule",
+				slog.Int64("chat_id", chat.ChatID),
+				slog.String("error", err.Error()),
+			)
+
+			continue
+		}
+
+		if !schedule.Due(chat.SentAt, now) {
+			continue
+		}
+
+		claimed, err := n.Storage.ClaimDigest(ctx, chat.ChatID, chat.SentAt, now)
+		if err != nil {
+			slog.Error("notifier: failed to claim digest",
+				slog.Int64("chat_id", chat.ChatID),
+				slog.String("error", err.Error()),
+			)
+
+			continue
+		}
+
+		if !claimed {
+			continue
+		}
+
 		select {
 		case <-ctx.Done():
 			return
@@ -95,9 +119,9 @@ func (n *Notifier) PushUpdates(ctx context.Context) {
 					<-n.Sem
 				}()
 
-				if err := n.ProcessChat(ctx, chatID); err != nil {
+				if err := n.ProcessChat(ctx, chat.ChatID); err != nil {
 					slog.Error("notifier: processing failed",
-						slog.Int64("chat_id", chatID),
+						slog.Int64("chat_id", chat.ChatID),
 						slog.String("error", err.Error()),
 					)
 				}
@@ -106,21 +130,54 @@ func (n *Notifier) PushUpdates(ctx context.Context) {
 	}
 }
 
+// ProcessChat sends the chat a single digest of the updates pending on all of its links.
+// The cursors of the subscriptions advance only once the digest is out. A link whose
+// updates cannot be collected is left for the next run instead of holding up the rest.
 func (n *Notifier) ProcessChat(ctx context.Context, chatID int64) error {
-	links, err := n.Storage.GetLinksWithChatActive(ctx, chatID)
+	links, err := n.Storage.GetLinksWithChatPending(ctx, chatID)
 	if err != nil {
 		return fmt.Errorf("failed to get chat links: %w", err)
 	}
 
+	chat := models.ChatDigest{ChatID: chatID}
+	batches := make([]batch, 0, len(links))
+
 	for _, link := range links {
-		if err := n.Notify(ctx, chatID, link.Url); err != nil {
-			return err
+		b, err := n.collect(ctx, chatID, link)
+		if err != nil {
+			slog.Error("notifier: failed to collect link updates",
+				slog.Int64("chat_id", chatID),
+				slog.Int64("link_id", link.Id),
+				slog.String("error", err.Error()),
+			)
+
+			continue
+		}
+
+		if b.digest.Total > 0 {
+			chat.Links = append(chat.Links, b.digest)
+		}
+
+		batches = append(batches, b)
+	}
+
+	if len(chat.Links) > 0 {
+		if err := n.Sender.SendDigest(ctx, chat); err != nil {
+			return fmt.Errorf("failed to send digest: %w", err)
+		}
+	}
+
+	for _, b := range batches {
+		if !b.moved {
+			continue
 		}
 
-		if err := n.Storage.UpdateLinkActivity(ctx, link.Id, false); err != nil {
+		if err := n.Storage.UpdateSubscriptionCursor(ctx, chatID, b.linkID, b.cursor); err != nil {
 			return err
 		}
 	}
 
 	return nil
 }
+
+const everyMinute = "* * * * *"
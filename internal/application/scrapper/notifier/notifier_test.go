@@ -2,6 +2,7 @@ package notifier_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -13,66 +14,204 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func TestNotify(t *testing.T) {
-	client := mocks.NewMockExternalClient(t)
-	defer client.AssertExpectations(t)
+func TestProcessChat(t *testing.T) {
+	storage := mocks.NewMockNotifierStorage(t)
+	defer storage.AssertExpectations(t)
 
 	sender := mocks.NewMockUpdateSender(t)
 	defer sender.AssertExpectations(t)
 
 	ctx := context.Background()
 
-	client.On("RetrieveUpdates", mock.Anything, "https://github.com/example/repo").
-		Once().Return([]models.Update{{CreatedAt: time.Now().Format(time.RFC3339)}}, nil)
+	at := time.Now().Truncate(time.Second)
+	cursor := models.NewCursor(at.Add(-time.Hour), "")
+
+	storage.On("GetLinksWithChatPending", mock.Anything, int64(1)).
+		Return([]sapi.LinkResponse{
+			{Id: 1, Url: "https://github.com/example/repo"},
+			{Id: 2, Url: "https://stackoverflow.com/questions/1"},
+		}, nil)
+
+	storage.On("GetSubscriptionCursor", mock.Anything, int64(1), int64(1)).
+		Return(cursor, nil)
+	storage.On("GetSubscriptionCursor", mock.Anything, int64(1), int64(2)).
+		Return(cursor, nil)
+
+	storage.On("GetPendingUpdates", mock.Anything, int64(1), cursor).
+		Return([]models.Update{
+			{ID: "1", Kind: models.KindIssue, CreatedAt: at.Add(-time.Minute)},
+			{ID: "2", Kind: models.KindPR, CreatedAt: at},
+		}, nil)
+	storage.On("GetPendingUpdates", mock.Anything, int64(2), cursor).
+		Return([]models.Update{}, nil)
+
+	storage.On("UpdateSubscriptionCursor", mock.Anything, int64(1), int64(1),
+		mock.MatchedBy(func(next models.Cursor) bool {
+			return next.At.Equal(at) && next.ID == "2"
+		})).
+		Once().Return(nil)
 
-	sender.On("Send", mock.Anything, mock.AnythingOfType("int64"), mock.AnythingOfType("string"),
-		mock.AnythingOfType("string")).
+	sender.On("SendDigest", mock.Anything, mock.MatchedBy(func(digest models.ChatDigest) bool {
+		return digest.ChatID == 1 && len(digest.Links) == 1 &&
+			digest.Links[0].URL == "https://github.com/example/repo" && digest.Links[0].Total == 2
+	})).
 		Once().Return(nil)
 
 	n := &notifier.Notifier{
-		GitHub: client,
-		Sender: sender,
+		Storage: storage,
+		Sender:  sender,
 	}
 
-	err := n.Notify(ctx, 1, "https://github.com/example/repo")
+	err := n.ProcessChat(ctx, 1)
 	require.NoError(t, err)
 }
 
-func TestProcessChat(t *testing.T) {
+func TestProcessChatFilters(t *testing.T) {
 	storage := mocks.NewMockNotifierStorage(t)
 	defer storage.AssertExpectations(t)
 
-	client := mocks.NewMockExternalClient(t)
-	defer client.AssertExpectations(t)
-
 	sender := mocks.NewMockUpdateSender(t)
 	defer sender.AssertExpectations(t)
 
 	ctx := context.Background()
 
-	links := []sapi.LinkResponse{
-		{Id: 1, Url: "https://github.com/example/repo"},
+	storage.On("GetLinksWithChatPending", mock.Anything, int64(1)).
+		Return([]sapi.LinkResponse{{
+			Id:      1,
+			Url:     "https://github.com/example/repo",
+			Filters: []string{"stars:>500", "-user:dependabot"},
+		}}, nil)
+
+	storage.On("GetSubscriptionCursor", mock.Anything, int64(1), int64(1)).
+		Return(models.NewCursor(time.Now().Add(-time.Hour), ""), nil)
+
+	storage.On("GetPendingUpdates", mock.Anything, int64(1), mock.AnythingOfType("models.Cursor")).
+		Return([]models.Update{
+			models.NewUpdate("1", models.KindPR, "Bump deps", "", time.Now(), "dependabot", ""),
+			models.NewUpdate("2", models.KindPR, "Fix crash", "", time.Now(), "gopher", ""),
+		}, nil)
+
+	storage.On("UpdateSubscriptionCursor", mock.Anything, int64(1), int64(1), mock.AnythingOfType("models.Cursor")).
+		Once().Return(nil)
+
+	sender.On("SendDigest", mock.Anything, mock.MatchedBy(func(digest models.ChatDigest) bool {
+		return len(digest.Links) == 1 && len(digest.Links[0].Updates) == 1 &&
+			digest.Links[0].Updates[0].Title == "Fix crash"
+	})).
+		Once().Return(nil)
+
+	n := &notifier.Notifier{
+		Storage: storage,
+		Sender:  sender,
 	}
 
-	storage.On("GetLinksWithChatActive", mock.Anything, mock.Anything).
-		Return(links, nil)
+	err := n.ProcessChat(ctx, 1)
+	require.NoError(t, err)
+}
 
-	storage.On("UpdateLinkActivity", mock.Anything, mock.Anything, mock.Anything).
-		Return(nil)
+func TestProcessChatSkipsFailingLink(t *testing.T) {
+	storage := mocks.NewMockNotifierStorage(t)
+	defer storage.AssertExpectations(t)
 
-	client.On("RetrieveUpdates", mock.Anything, "https://github.com/example/repo").
-		Return([]models.Update{{CreatedAt: time.Now().Format(time.RFC3339)}}, nil)
+	sender := mocks.NewMockUpdateSender(t)
+	defer sender.AssertExpectations(t)
 
-	sender.On("Send", mock.Anything, mock.AnythingOfType("int64"), mock.AnythingOfType("string"),
-		mock.AnythingOfType("string")).
+	ctx := context.Background()
+	cursor := models.NewCursor(time.Now().Add(-time.Hour), "")
+
+	storage.On("GetLinksWithChatPending", mock.Anything, int64(1)).
+		Return([]sapi.LinkResponse{
+			{Id: 1, Url: "https://github.com/example/broken"},
+			{Id: 2, Url: "https://github.com/example/repo"},
+		}, nil)
+
+	storage.On("GetSubscriptionCursor", mock.Anything, int64(1), int64(1)).
+		Return(models.Cursor{}, errors.New("connection reset"))
+	storage.On("GetSubscriptionCursor", mock.Anything, int64(1), int64(2)).
+		Return(cursor, nil)
+
+	storage.On("GetPendingUpdates", mock.Anything, int64(2), cursor).
+		Return([]models.Update{{ID: "1", Kind: models.KindIssue, CreatedAt: time.Now()}}, nil)
+
+	storage.On("UpdateSubscriptionCursor", mock.Anything, int64(1), int64(2), mock.AnythingOfType("models.Cursor")).
+		Once().Return(nil)
+
+	sender.On("SendDigest", mock.Anything, mock.MatchedBy(func(digest models.ChatDigest) bool {
+		return len(digest.Links) == 1 && digest.Links[0].URL == "https://github.com/example/repo"
+	})).
 		Once().Return(nil)
 
 	n := &notifier.Notifier{
 		Storage: storage,
-		GitHub:  client,
 		Sender:  sender,
 	}
 
 	err := n.ProcessChat(ctx, 1)
 	require.NoError(t, err)
 }
+
+func TestProcessChatKeepsCursorsWhenDigestFails(t *testing.T) {
+	storage := mocks.NewMockNotifierStorage(t)
+	defer storage.AssertExpectations(t)
+
+	sender := mocks.NewMockUpdateSender(t)
+	defer sender.AssertExpectations(t)
+
+	ctx := context.Background()
+
+	storage.On("GetLinksWithChatPending", mock.Anything, mock.Anything).
+		Return([]sapi.LinkResponse{{Id: 1, Url: "https://github.com/example/repo"}}, nil)
+
+	storage.On("GetSubscriptionCursor", mock.Anything, mock.Anything, mock.Anything).
+		Return(models.NewCursor(time.Now().Add(-time.Hour), ""), nil)
+
+	storage.On("GetPendingUpdates", mock.Anything, mock.Anything, mock.Anything).
+		Return([]models.Update{{ID: "1", CreatedAt: time.Now()}}, nil)
+
+	sender.On("SendDigest", mock.Anything, mock.AnythingOfType("models.ChatDigest")).
+		Once().Return(errors.New("bot is down"))
+
+	n := &notifier.Notifier{
+		Storage: storage,
+		Sender:  sender,
+	}
+
+	err := n.ProcessChat(ctx, 1)
+	require.Error(t, err)
+	storage.AssertNotCalled(t, "UpdateSubscriptionCursor", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPushUpdatesRespectsChatSchedule(t *testing.T) {
+	storage := mocks.NewMockNotifierStorage(t)
+	defer storage.AssertExpectations(t)
+
+	ctx := context.Background()
+	now := time.Date(2025, 6, 4, 10, 0, 0, 0, time.UTC)
+
+	storage.On("GetChatDigests", mock.Anything).
+		Return([]models.Digest{
+			models.NewDigest(1, "UTC", "10:00", now.Add(-23*time.Hour)),
+			models.NewDigest(2, "Asia/Tokyo", "10:00", now.Add(-9*time.Hour)),
+			models.NewDigest(3, "UTC", "0 9 * * *", now.Add(-30*time.Minute)),
+			models.NewDigest(4, "UTC", "10:00", now.Add(-23*time.Hour)),
+		}, nil)
+
+	storage.On("ClaimDigest", mock.Anything, int64(1), now.Add(-23*time.Hour), now).
+		Once().Return(true, nil)
+
+	// Another replica has already claimed this slot.
+	storage.On("ClaimDigest", mock.Anything, int64(4), now.Add(-23*time.Hour), now).
+		Once().Return(false, nil)
+
+	storage.On("GetLinksWithChatPending", mock.Anything, int64(1)).
+		Once().Return([]sapi.LinkResponse{}, nil)
+
+	n := &notifier.Notifier{
+		Storage: storage,
+		Sem:     make(chan struct{}, 1),
+	}
+
+	n.PushUpdates(ctx, now)
+
+	n.Sem <- struct{}{}
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"github.com/es-debug/backend-academy-2024-go-template/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -20,7 +21,7 @@ type Server interface {
 }
 
 type Updater interface {
-	Send(ctx context.Context, chatID int64, url, description string) error
+	Send(ctx context.Context, chatID int64, url string, update models.Update) error
 }
 
 type ScrapperService struct {
@@ -4,8 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	sapi "github.com/es-debug/backend-academy-2024-go-template/internal/api/openapi/v1/servers/scrapper"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/sources"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
 )
 
 type ChatsRepository interface {
@@ -13,30 +16,51 @@ type ChatsRepository interface {
 	Delete(ctx context.Context, chatID int64) error
 	ExistsID(ctx context.Context, chatID int64) error
 	GetIDs(ctx context.Context) ([]int64, error)
+	GetDigest(ctx context.Context, chatID int64) (models.Digest, error)
+	GetDigests(ctx context.Context) ([]models.Digest, error)
+	UpdateDigest(ctx context.Context, chatID int64, timezone, schedule string) error
+	ClaimDigest(ctx context.Context, chatID int64, prev, at time.Time) (bool, error)
+	GetTemplate(ctx context.Context, chatID int64) (string, error)
+	UpdateTemplate(ctx context.Context, chatID int64, template string) error
 }
 
 type LinksRepository interface {
 	Add(ctx context.Context, url string) (int64, error)
 	Delete(ctx context.Context, linkID int64) error
+	DeleteUnused(ctx context.Context, linkID int64) error
 	Touch(ctx context.Context, linkID int64) error
 	UpdateActivity(ctx context.Context, linkID int64, status bool) error
+	MarkChecked(ctx context.Context, linkID int64, result models.CheckResult) error
+	GetSchedule(ctx context.Context, linkID int64) (models.Schedule, error)
+	Lease(ctx context.Context, linkIDs []int64, until time.Time) error
 	GetBatch(ctx context.Context, batch uint64) ([]sapi.LinkResponse, error)
 }
 
 type SubsRepository interface {
-	Add(ctx context.Context, chatID, linkID int64) error
+	Add(ctx context.Context, chatID, linkID int64, mode string) error
 	Delete(ctx context.Context, chatID, linkID int64) error
 	GetLinkID(ctx context.Context, url string, chatID int64) (int64, error)
 	GetLinksWithChat(ctx context.Context, chatID int64) ([]sapi.LinkResponse, error)
 	GetLinksWithChatActive(ctx context.Context, chatID int64) ([]sapi.LinkResponse, error)
+	GetLinksWithChatPending(ctx context.Context, chatID int64) ([]sapi.LinkResponse, error)
+	GetCursor(ctx context.Context, chatID, linkID int64) (models.Cursor, error)
+	GetOldestCursor(ctx context.Context, linkID int64) (models.Cursor, error)
+	GetInstantSubscribers(ctx context.Context, linkID int64) ([]models.Subscriber, error)
+	UpdateCursor(ctx context.Context, chatID, linkID int64, cursor models.Cursor) error
 }
 
 type TagsRepository interface {
-	Add(ctx context.Context, tag string, linkID int64) error
+	Add(ctx context.Context, tag string, chatID, linkID int64) error
 }
 
 type FiltersRepository interface {
-	Add(ctx context.Context, filter string, linkID int64) error
+	Add(ctx context.Context, filter string, chatID, linkID int64) error
+}
+
+type UpdatesRepository interface {
+	Add(ctx context.Context, linkID int64, update models.Update) (bool, error)
+	GetLatest(ctx context.Context, linkID int64) (models.Cursor, error)
+	GetSince(ctx context.Context, linkID int64, cursor models.Cursor) ([]models.Update, error)
 }
 
 type Transactor interface {
@@ -49,6 +73,7 @@ type Storage struct {
 	subs    SubsRepository
 	tags    TagsRepository
 	filters FiltersRepository
+	updates UpdatesRepository
 	tx      Transactor
 }
 
@@ -58,6 +83,7 @@ func New(
 	subs SubsRepository,
 	tags TagsRepository,
 	filters FiltersRepository,
+	updates UpdatesRepository,
 	tx Transactor,
 ) *Storage {
 	return &Storage{
@@ -66,6 +92,7 @@ func New(
 		subs:    subs,
 		tags:    tags,
 		filters: filters,
+		updates: updates,
 		tx:      tx,
 	}
 }
@@ -82,6 +109,30 @@ func (s *Storage) ExistsChat(ctx context.Context, chatID int64) error {
 	return s.chats.ExistsID(ctx, chatID)
 }
 
+func (s *Storage) GetChatDigest(ctx context.Context, chatID int64) (models.Digest, error) {
+	return s.chats.GetDigest(ctx, chatID)
+}
+
+func (s *Storage) GetChatDigests(ctx context.Context) ([]models.Digest, error) {
+	return s.chats.GetDigests(ctx)
+}
+
+func (s *Storage) UpdateChatDigest(ctx context.Context, chatID int64, timezone, schedule string) error {
+	return s.chats.UpdateDigest(ctx, chatID, timezone, schedule)
+}
+
+func (s *Storage) ClaimDigest(ctx context.Context, chatID int64, prev, at time.Time) (bool, error) {
+	return s.chats.ClaimDigest(ctx, chatID, prev, at)
+}
+
+func (s *Storage) GetChatTemplate(ctx context.Context, chatID int64) (string, error) {
+	return s.chats.GetTemplate(ctx, chatID)
+}
+
+func (s *Storage) UpdateChatTemplate(ctx context.Context, chatID int64, template string) error {
+	return s.chats.UpdateTemplate(ctx, chatID, template)
+}
+
 func (s *Storage) AddLink(ctx context.Context, link sapi.AddLinkRequest, chatID int64) (int64, error) {
 	var linkID int64
 
@@ -91,24 +142,30 @@ func (s *Storage) AddLink(ctx context.Context, link sapi.AddLinkRequest, chatID
 			return fmt.Errorf("storage: %w", sapi.ErrLinkAlreadyExists)
 		}
 
+		// A url already tracked by another chat resolves to its existing link.
 		id, err := s.links.Add(ctx, link.Link)
 		if err != nil {
 			return fmt.Errorf("storage: failed to create link: %w", err)
 		}
 		linkID = id
 
-		if err = s.subs.Add(ctx, chatID, linkID); err != nil {
+		mode := sapi.Digest
+		if link.Mode != nil {
+			mode = *link.Mode
+		}
+
+		if err = s.subs.Add(ctx, chatID, linkID, string(mode)); err != nil {
 			return fmt.Errorf("storage: failed to create subscription: %w", err)
 		}
 
 		for _, tag := range link.Tags {
-			if err = s.tags.Add(ctx, tag, linkID); err != nil {
+			if err = s.tags.Add(ctx, tag, chatID, linkID); err != nil {
 				return fmt.Errorf("storage: failed to add tag: %w", err)
 			}
 		}
 
 		for _, filter := range link.Filters {
-			if err = s.filters.Add(ctx, filter, linkID); err != nil {
+			if err = s.filters.Add(ctx, filter, chatID, linkID); err != nil {
 				return fmt.Errorf("storage: failed to add filter: %w", err)
 			}
 		}
@@ -129,21 +186,77 @@ func (s *Storage) DeleteLink(ctx context.Context, link sapi.RemoveLinkRequest, c
 		return fmt.Errorf("storage: %w", err)
 	}
 
-	if err = s.links.Delete(ctx, linkID); err != nil {
-		return fmt.Errorf("storage: failed to delete link: %w", err)
+	// Tags and filters of the subscription go with it, the link itself
+	// is kept for as long as some other chat still tracks it.
+	err = s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.subs.Delete(ctx, chatID, linkID); err != nil {
+			return fmt.Errorf("storage: failed to delete subscription: %w", err)
+		}
+
+		if err := s.links.DeleteUnused(ctx, linkID); err != nil {
+			return fmt.Errorf("storage: failed to delete link: %w", err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("storage: transaction failed: %w", err)
 	}
 
 	return nil
 }
 
-func (s *Storage) GetLinks(ctx context.Context, batch uint64) ([]sapi.LinkResponse, error) {
-	return s.links.GetBatch(ctx, batch)
+// LeaseLinks hands out due links to a single replica. Rows locked by a concurrent
+// lease are skipped, and the lease expires on its own if the replica dies before
+// marking the links checked.
+func (s *Storage) LeaseLinks(ctx context.Context, batch uint64, ttl time.Duration) ([]sapi.LinkResponse, error) {
+	var links []sapi.LinkResponse
+
+	err := s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		due, err := s.links.GetBatch(ctx, batch)
+		if err != nil {
+			return fmt.Errorf("storage: failed to get due links: %w", err)
+		}
+
+		if len(due) == 0 {
+			links = due
+			return nil
+		}
+
+		ids := make([]int64, 0, len(due))
+		for _, link := range due {
+			ids = append(ids, link.Id)
+		}
+
+		if err = s.links.Lease(ctx, ids, time.Now().Add(ttl)); err != nil {
+			return fmt.Errorf("storage: failed to lease links: %w", err)
+		}
+
+		links = due
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("storage: transaction failed: %w", err)
+	}
+
+	return links, nil
 }
 
 func (s *Storage) TouchLink(ctx context.Context, linkID int64) error {
 	return s.links.Touch(ctx, linkID)
 }
 
+func (s *Storage) MarkLinkChecked(ctx context.Context, linkID int64, result models.CheckResult) error {
+	return s.links.MarkChecked(ctx, linkID, result)
+}
+
+func (s *Storage) GetLinkSchedule(ctx context.Context, linkID int64) (models.Schedule, error) {
+	return s.links.GetSchedule(ctx, linkID)
+}
+
 func (s *Storage) GetLinksWithChat(ctx context.Context, chatID int64) ([]sapi.LinkResponse, error) {
 
 	return s.subs.GetLinksWithChat(ctx, chatID)
@@ -153,6 +266,10 @@ func (s *Storage) GetLinksWithChatActive(ctx context.Context, chatID int64) ([]s
 	return s.subs.GetLinksWithChatActive(ctx, chatID)
 }
 
+func (s *Storage) GetLinksWithChatPending(ctx context.Context, chatID int64) ([]sapi.LinkResponse, error) {
+	return s.subs.GetLinksWithChatPending(ctx, chatID)
+}
+
 func (s *Storage) UpdateLinkActivity(ctx context.Context, linkID int64, status bool) error {
 	return s.links.UpdateActivity(ctx, linkID, status)
 }
@@ -162,5 +279,71 @@ func (s *Storage) GetChatIDs(ctx context.Context) ([]int64, error) {
 }
 
 func (s *Storage) GetLinkID(ctx context.Context, url string, chatID int64) (int64, error) {
-	return s.subs.GetLinkID(ctx, url, chatID)
+	canonical, err := sources.Canonical(url)
+	if err != nil {
+		return 0, fmt.Errorf("storage: failed to canonicalise link: %w", err)
+	}
+
+	return s.subs.GetLinkID(ctx, canonical, chatID)
+}
+
+func (s *Storage) GetInstantSubscribers(ctx context.Context, linkID int64) ([]models.Subscriber, error) {
+	return s.subs.GetInstantSubscribers(ctx, linkID)
+}
+
+func (s *Storage) GetSubscriptionCursor(ctx context.Context, chatID, linkID int64) (models.Cursor, error) {
+	return s.subs.GetCursor(ctx, chatID, linkID)
+}
+
+func (s *Storage) UpdateSubscriptionCursor(ctx context.Context, chatID, linkID int64, cursor models.Cursor) error {
+	return s.subs.UpdateCursor(ctx, chatID, linkID, cursor)
+}
+
+// GetLinkCursor returns the position the next fetch of the link should start from:
+// whichever is later of the latest stored update and the oldest subscriber cursor.
+func (s *Storage) GetLinkCursor(ctx context.Context, linkID int64) (models.Cursor, error) {
+	oldest, err := s.subs.GetOldestCursor(ctx, linkID)
+	if err != nil {
+		return models.Cursor{}, fmt.Errorf("storage: %w", err)
+	}
+
+	latest, err := s.updates.GetLatest(ctx, linkID)
+	if err != nil {
+		return models.Cursor{}, fmt.Errorf("storage: %w", err)
+	}
+
+	if oldest.Precedes(latest.At, latest.ID) {
+		return latest, nil
+	}
+
+	return oldest, nil
+}
+
+func (s *Storage) SaveUpdates(ctx context.Context, linkID int64, updates []models.Update) (int, error) {
+	var saved int
+
+	err := s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		for _, update := range updates {
+			inserted, err := s.updates.Add(ctx, linkID, update)
+			if err != nil {
+				return fmt.Errorf("storage: failed to save update: %w", err)
+			}
+
+			if inserted {
+				saved++
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, fmt.Errorf("storage: transaction failed: %w", err)
+	}
+
+	return saved, nil
+}
+
+func (s *Storage) GetPendingUpdates(ctx context.Context, linkID int64, cursor models.Cursor) ([]models.Update, error) {
+	return s.updates.GetSince(ctx, linkID, cursor)
 }
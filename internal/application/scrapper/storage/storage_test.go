@@ -10,6 +10,7 @@ import (
 	"github.com/es-debug/backend-academy-2024-go-template/config"
 	sapi "github.com/es-debug/backend-academy-2024-go-template/internal/api/openapi/v1/servers/scrapper"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/application/scrapper/storage"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/repository/chats"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/repository/db"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/repository/filters"
@@ -17,6 +18,8 @@ import (
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/repository/subs"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/repository/tags"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/repository/txs"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/repository/updates"
+	_ "github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/sources/all"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/stretchr/testify/require"
 	"github.com/testcontainers/testcontainers-go"
@@ -92,6 +95,8 @@ func TestAddAndGetLinks(t *testing.T) {
 			st := setupTestStorage(t, test.access)
 			ctx := context.Background()
 
+			instant := sapi.Instant
+
 			testLinks := []struct {
 				chatID int64
 				link   sapi.AddLinkRequest
@@ -118,6 +123,7 @@ func TestAddAndGetLinks(t *testing.T) {
 						Link:    "https://github.com/example/repo3",
 						Tags:    []string{"python", "ml"},
 						Filters: []string{"stars:>500", "license:apache"},
+						Mode:    &instant,
 					},
 				},
 			}
@@ -162,6 +168,24 @@ func TestAddAndGetLinks(t *testing.T) {
 				require.ElementsMatch(t, []int64{1, 2}, chatIDs)
 			})
 
+			t.Run("instant subscribers", func(t *testing.T) {
+				linkID, err := st.GetLinkID(ctx, "https://github.com/example/repo3", 2)
+				require.NoError(t, err)
+
+				subscribers, err := st.GetInstantSubscribers(ctx, linkID)
+				require.NoError(t, err)
+				require.Len(t, subscribers, 1)
+				require.Equal(t, int64(2), subscribers[0].ChatID)
+				require.ElementsMatch(t, []string{"stars:>500", "license:apache"}, subscribers[0].Filters)
+
+				linkID, err = st.GetLinkID(ctx, "https://github.com/example/repo", 1)
+				require.NoError(t, err)
+
+				subscribers, err = st.GetInstantSubscribers(ctx, linkID)
+				require.NoError(t, err)
+				require.Empty(t, subscribers)
+			})
+
 			t.Run("repeated link", func(t *testing.T) {
 				_, err := st.AddLink(ctx, testLinks[0].link, testLinks[0].chatID)
 				require.Error(t, err)
@@ -219,6 +243,84 @@ func TestLinkDeletion(t *testing.T) {
 	}
 }
 
+func TestSharedLink(t *testing.T) {
+	tests := map[string]struct {
+		access string
+	}{
+		"squirrel repository": {access: config.Orm},
+		"sql repository":      {access: config.Sql},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			st := setupTestStorage(t, test.access)
+			ctx := context.Background()
+
+			url := "https://github.com/example/shared"
+
+			for _, chatID := range []int64{1, 2} {
+				if err := st.ExistsChat(ctx, chatID); err != nil {
+					require.NoError(t, st.AddChat(ctx, chatID))
+				}
+			}
+
+			first, err := st.AddLink(ctx, sapi.AddLinkRequest{
+				Link:    url,
+				Tags:    []string{"work"},
+				Filters: []string{"user:alice"},
+			}, 1)
+			require.NoError(t, err)
+
+			second, err := st.AddLink(ctx, sapi.AddLinkRequest{
+				Link:    url,
+				Tags:    []string{"hobby"},
+				Filters: []string{},
+			}, 2)
+			require.NoError(t, err)
+
+			t.Run("one link per url", func(t *testing.T) {
+				require.Equal(t, first, second)
+
+				schedule, err := st.GetLinkSchedule(ctx, first)
+				require.NoError(t, err)
+				require.Equal(t, 2, schedule.Subscribers)
+
+				linkID, err := st.GetLinkID(ctx, "www.github.com/Example/Shared/?tab=readme", 1)
+				require.NoError(t, err)
+				require.Equal(t, first, linkID)
+			})
+
+			t.Run("tags and filters per subscription", func(t *testing.T) {
+				links, err := st.GetLinksWithChat(ctx, 1)
+				require.NoError(t, err)
+				require.Len(t, links, 1)
+				require.Equal(t, []string{"work"}, links[0].Tags)
+				require.Equal(t, []string{"user:alice"}, links[0].Filters)
+
+				links, err = st.GetLinksWithChat(ctx, 2)
+				require.NoError(t, err)
+				require.Len(t, links, 1)
+				require.Equal(t, []string{"hobby"}, links[0].Tags)
+				require.Empty(t, links[0].Filters)
+			})
+
+			t.Run("link outlives a single unsubscribe", func(t *testing.T) {
+				require.NoError(t, st.DeleteLink(ctx, sapi.RemoveLinkRequest{Link: url}, 1))
+
+				linkID, err := st.GetLinkID(ctx, url, 2)
+				require.NoError(t, err)
+				require.Equal(t, first, linkID)
+
+				require.NoError(t, st.DeleteLink(ctx, sapi.RemoveLinkRequest{Link: url}, 2))
+
+				schedule, err := st.GetLinkSchedule(ctx, first)
+				require.Error(t, err)
+				require.Zero(t, schedule.Subscribers)
+			})
+		})
+	}
+}
+
 func TestLinkActivity(t *testing.T) {
 	tests := map[string]struct {
 		access string
@@ -258,6 +360,135 @@ func TestLinkActivity(t *testing.T) {
 	}
 }
 
+func TestLinkSchedule(t *testing.T) {
+	tests := map[string]struct {
+		access string
+	}{
+		"squirrel repository": {access: config.Orm},
+		"sql repository":      {access: config.Sql},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			st := setupTestStorage(t, test.access)
+			ctx := context.Background()
+
+			chatID := int64(1)
+			require.NoError(t, st.AddChat(ctx, chatID))
+
+			linkID, err := st.AddLink(ctx, sapi.AddLinkRequest{Link: "https://github.com/example/schedule"}, chatID)
+			require.NoError(t, err)
+
+			due, err := st.LeaseLinks(ctx, 10, time.Minute)
+			require.NoError(t, err)
+			require.Len(t, due, 1)
+
+			leased, err := st.LeaseLinks(ctx, 10, time.Minute)
+			require.NoError(t, err)
+			require.Empty(t, leased)
+
+			schedule, err := st.GetLinkSchedule(ctx, linkID)
+			require.NoError(t, err)
+			require.Zero(t, schedule.Interval)
+			require.Equal(t, 1, schedule.Subscribers)
+			require.Nil(t, schedule.Score)
+
+			score := int64(27)
+
+			result := models.NewCheckResult(0, nil)
+			result.Interval = 10 * time.Minute
+			result.NextCheckAt = time.Now().Add(time.Hour)
+			result.Score = &score
+			require.NoError(t, st.MarkLinkChecked(ctx, linkID, result))
+
+			schedule, err = st.GetLinkSchedule(ctx, linkID)
+			require.NoError(t, err)
+			require.Equal(t, 10*time.Minute, schedule.Interval)
+			require.Equal(t, &score, schedule.Score)
+
+			due, err = st.LeaseLinks(ctx, 10, time.Minute)
+			require.NoError(t, err)
+			require.Empty(t, due)
+
+			// A check without a score keeps the stored one.
+			result.NextCheckAt = time.Now().Add(-time.Minute)
+			result.Score = nil
+			require.NoError(t, st.MarkLinkChecked(ctx, linkID, result))
+
+			schedule, err = st.GetLinkSchedule(ctx, linkID)
+			require.NoError(t, err)
+			require.Equal(t, &score, schedule.Score)
+
+			due, err = st.LeaseLinks(ctx, 10, time.Minute)
+			require.NoError(t, err)
+			require.Len(t, due, 1)
+		})
+	}
+}
+
+func TestPendingUpdates(t *testing.T) {
+	tests := map[string]struct {
+		access string
+	}{
+		"squirrel repository": {access: config.Orm},
+		"sql repository":      {access: config.Sql},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			st := setupTestStorage(t, test.access)
+			ctx := context.Background()
+
+			chatID := int64(1)
+			require.NoError(t, st.AddChat(ctx, chatID))
+
+			linkID, err := st.AddLink(ctx, sapi.AddLinkRequest{Link: "https://github.com/example/pending"}, chatID)
+			require.NoError(t, err)
+
+			cursor, err := st.GetSubscriptionCursor(ctx, chatID, linkID)
+			require.NoError(t, err)
+
+			at := cursor.At.Add(time.Minute).UTC().Truncate(time.Second)
+			updates := []models.Update{
+				models.NewUpdate("1", models.KindIssue, "first", "", at, "gopher", ""),
+				models.NewUpdate("2", models.KindPR, "second", "", at, "gopher", "", "bug"),
+			}
+
+			t.Run("save is idempotent", func(t *testing.T) {
+				saved, err := st.SaveUpdates(ctx, linkID, updates)
+				require.NoError(t, err)
+				require.Equal(t, 2, saved)
+
+				saved, err = st.SaveUpdates(ctx, linkID, updates)
+				require.NoError(t, err)
+				require.Zero(t, saved)
+
+				next, err := st.GetLinkCursor(ctx, linkID)
+				require.NoError(t, err)
+				require.True(t, next.At.Equal(at))
+				require.Equal(t, "2", next.ID)
+			})
+
+			t.Run("delivery advances cursor", func(t *testing.T) {
+				pendingLinks, err := st.GetLinksWithChatPending(ctx, chatID)
+				require.NoError(t, err)
+				require.Len(t, pendingLinks, 1)
+
+				pending, err := st.GetPendingUpdates(ctx, linkID, cursor)
+				require.NoError(t, err)
+				require.Len(t, pending, 2)
+				require.Equal(t, []string{"bug"}, pending[1].Labels)
+
+				require.NoError(t, st.UpdateSubscriptionCursor(ctx, chatID, linkID, models.NewCursor(at, "2")))
+
+				pendingLinks, err = st.GetLinksWithChatPending(ctx, chatID)
+				require.NoError(t, err)
+				require.Empty(t, pendingLinks)
+			})
+		})
+	}
+}
+
 func setupTestStorage(t *testing.T, access string) *storage.Storage {
 	ctx := context.Background()
 	require.NoError(t, clearTables(ctx, pool))
@@ -270,6 +501,7 @@ func setupTestStorage(t *testing.T, access string) *storage.Storage {
 		subs.New(testDBConfig, pool),
 		tags.New(testDBConfig, pool),
 		filters.New(testDBConfig, pool),
+		updates.New(testDBConfig, pool),
 		txs.New(pool),
 	)
 }
@@ -281,7 +513,8 @@ func clearTables(ctx context.Context, pool *pgxpool.Pool) error {
 			links, 
 			subs, 
 			tags, 
-			filters 
+			filters,
+			updates
 		CASCADE
 	`)
 	return err
@@ -7,15 +7,19 @@ import (
 
 	"github.com/es-debug/backend-academy-2024-go-template/config"
 	bclient "github.com/es-debug/backend-academy-2024-go-template/internal/api/openapi/v1/clients/bot"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
 )
 
 type HTTPSender interface {
 	PostUpdates(ctx context.Context, body bclient.PostUpdatesJSONRequestBody,
 		reqEditors ...bclient.RequestEditorFn) (*http.Response, error)
+	PostDigests(ctx context.Context, body bclient.PostDigestsJSONRequestBody,
+		reqEditors ...bclient.RequestEditorFn) (*http.Response, error)
 }
 
 type KafkaSender interface {
-	Send(ctx context.Context, chatID int64, url, description string) error
+	Send(ctx context.Context, chatID int64, url string, update models.Update) error
+	SendDigest(ctx context.Context, digest models.ChatDigest) error
 }
 
 type Updater struct {
@@ -36,17 +40,33 @@ func New(
 	}
 }
 
-func (u *Updater) Send(ctx context.Context, chatID int64, url, description string) error {
+func (u *Updater) Send(ctx context.Context, chatID int64, url string, update models.Update) error {
+	return u.deliver(
+		func() error { return u.httpSend(ctx, chatID, url, update) },
+		func() error { return u.kafkaSend(ctx, chatID, url, update) },
+	)
+}
+
+// SendDigest delivers the digest of a chat as one payload over the same transports as single updates.
+func (u *Updater) SendDigest(ctx context.Context, digest models.ChatDigest) error {
+	return u.deliver(
+		func() error { return u.httpSendDigest(ctx, digest) },
+		func() error { return u.kafkaSender.SendDigest(ctx, digest) },
+	)
+}
+
+// deliver sends over the configured transport and falls back to the other one.
+func (u *Updater) deliver(viaHTTP, viaKafka func() error) error {
 	var primaryErr, secondaryErr error
 
 	switch u.transport {
 	case config.HTTPTransport:
-		if primaryErr = u.httpSend(ctx, chatID, url, description); primaryErr != nil {
-			secondaryErr = u.kafkaSend(ctx, chatID, url, description)
+		if primaryErr = viaHTTP(); primaryErr != nil {
+			secondaryErr = viaKafka()
 		}
 	case config.KafkaTransport:
-		if primaryErr = u.kafkaSend(ctx, chatID, url, description); primaryErr != nil {
-			secondaryErr = u.httpSend(ctx, chatID, url, description)
+		if primaryErr = viaKafka(); primaryErr != nil {
+			secondaryErr = viaHTTP()
 		}
 	default:
 		return ErrUnknownTransportMode
@@ -59,11 +79,11 @@ func (u *Updater) Send(ctx context.Context, chatID int64, url, description strin
 	return nil
 }
 
-func (u *Updater) httpSend(ctx context.Context, chatID int64, url, description string) error {
+func (u *Updater) httpSend(ctx context.Context, chatID int64, url string, update models.Update) error {
 	resp, err := u.httpSender.PostUpdates(ctx, bclient.PostUpdatesJSONRequestBody{
-		TgChatId:    chatID,
-		Url:         url,
-		Description: description,
+		TgChatId: chatID,
+		Url:      url,
+		Update:   toClient(update),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to post updates: %w", err)
@@ -77,6 +97,68 @@ func (u *Updater) httpSend(ctx context.Context, chatID int64, url, description s
 	return nil
 }
 
-func (u *Updater) kafkaSend(ctx context.Context, chatID int64, url, description string) error {
-	return u.kafkaSender.Send(ctx, chatID, url, description)
+func (u *Updater) httpSendDigest(ctx context.Context, digest models.ChatDigest) error {
+	body := bclient.PostDigestsJSONRequestBody{
+		TgChatId: digest.ChatID,
+		Links:    make([]bclient.LinkDigest, 0, len(digest.Links)),
+	}
+
+	for _, link := range digest.Links {
+		summary := bclient.LinkDigest{
+			Url:     link.URL,
+			Total:   link.Total,
+			Counts:  make([]bclient.KindCount, 0, len(link.Counts)),
+			Updates: make([]bclient.Update, 0, len(link.Updates)),
+		}
+
+		for _, count := range link.Counts {
+			summary.Counts = append(summary.Counts, bclient.KindCount{Kind: string(count.Kind), Count: count.Count})
+		}
+
+		for _, update := range link.Updates {
+			summary.Updates = append(summary.Updates, toClient(update))
+		}
+
+		body.Links = append(body.Links, summary)
+	}
+
+	resp, err := u.httpSender.PostDigests(ctx, body)
+	if err != nil {
+		return fmt.Errorf("failed to post digest: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return ErrHTTPSendUpdate
+	}
+
+	return nil
+}
+
+func (u *Updater) kafkaSend(ctx context.Context, chatID int64, url string, update models.Update) error {
+	return u.kafkaSender.Send(ctx, chatID, url, update)
+}
+
+func toClient(update models.Update) bclient.Update {
+	return bclient.Update{
+		Id:        update.ID,
+		Source:    update.Source,
+		Kind:      string(update.Kind),
+		Title:     update.Title,
+		Url:       update.URL,
+		Author:    update.Author,
+		Labels:    labels(update.Labels),
+		Body:      update.Body,
+		CreatedAt: update.CreatedAt,
+		UpdatedAt: update.UpdatedAt,
+	}
+}
+
+// labels keeps the list present in the payload, the schema requires it.
+func labels(labels []string) []string {
+	if labels == nil {
+		return []string{}
+	}
+
+	return labels
 }
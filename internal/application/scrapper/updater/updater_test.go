@@ -5,10 +5,12 @@ import (
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/es-debug/backend-academy-2024-go-template/config"
 	bclient "github.com/es-debug/backend-academy-2024-go-template/internal/api/openapi/v1/clients/bot"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/application/scrapper/updater"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -18,7 +20,9 @@ func TestUpdater_Send_HTTPPrimaryKafkaFallback(t *testing.T) {
 	ctx := context.Background()
 	chatID := int64(12345)
 	url := "https://example.com"
-	description := "test description"
+	at := time.Date(2025, 6, 7, 9, 0, 0, 0, time.UTC)
+	update := models.NewUpdate("42", models.KindIssue, "Crash", "https://example.com/issues/42", at, "gopher", "")
+	update.Source = "github"
 
 	tests := []struct {
 		name            string
@@ -109,9 +113,19 @@ func TestUpdater_Send_HTTPPrimaryKafkaFallback(t *testing.T) {
 
 			if tt.expectHTTPCall {
 				expectedBody := bclient.PostUpdatesJSONRequestBody{
-					TgChatId:    chatID,
-					Url:         url,
-					Description: description,
+					TgChatId: chatID,
+					Url:      url,
+					Update: bclient.Update{
+						Id:        "42",
+						Source:    "github",
+						Kind:      "issue",
+						Title:     "Crash",
+						Url:       "https://example.com/issues/42",
+						Author:    "gopher",
+						Labels:    []string{},
+						CreatedAt: at,
+						UpdatedAt: at,
+					},
 				}
 
 				if tt.httpError != nil {
@@ -129,10 +143,10 @@ func TestUpdater_Send_HTTPPrimaryKafkaFallback(t *testing.T) {
 
 			if tt.expectKafkaCall {
 				if tt.kafkaError != nil {
-					kafkaSenderMock.On("Send", mock.Anything, chatID, url, description).
+					kafkaSenderMock.On("Send", mock.Anything, chatID, url, update).
 						Return(tt.kafkaError)
 				} else {
-					kafkaSenderMock.On("Send", mock.Anything, chatID, url, description).
+					kafkaSenderMock.On("Send", mock.Anything, chatID, url, update).
 						Return(nil)
 				}
 			}
@@ -140,7 +154,72 @@ func TestUpdater_Send_HTTPPrimaryKafkaFallback(t *testing.T) {
 			upd := updater.New(httpSenderMock, kafkaSenderMock, tt.transport)
 
 			// Execute
-			err := upd.Send(ctx, chatID, url, description)
+			err := upd.Send(ctx, chatID, url, update)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			httpSenderMock.AssertExpectations(t)
+			kafkaSenderMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdater_SendDigest(t *testing.T) {
+	ctx := context.Background()
+	at := time.Date(2025, 6, 7, 9, 0, 0, 0, time.UTC)
+
+	digest := models.ChatDigest{
+		ChatID: 12345,
+		Links: []models.LinkDigest{{
+			URL:     "https://example.com",
+			Total:   1,
+			Counts:  []models.KindCount{{Kind: models.KindIssue, Count: 1}},
+			Updates: []models.Update{models.NewUpdate("42", models.KindIssue, "Crash", "https://example.com/issues/42", at, "gopher", "")},
+		}},
+	}
+
+	tests := map[string]struct {
+		httpError     error
+		kafkaError    error
+		expectKafka   bool
+		expectedError bool
+	}{
+		"http success - no fallback": {},
+		"http fails - fallback success": {
+			httpError:   errors.New("http connection failed"),
+			expectKafka: true,
+		},
+		"http fails - fallback fails": {
+			httpError:     errors.New("http connection failed"),
+			kafkaError:    errors.New("kafka connection failed"),
+			expectKafka:   true,
+			expectedError: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			httpSenderMock := mocks.NewMockHTTPSender(t)
+			kafkaSenderMock := mocks.NewMockKafkaSender(t)
+
+			httpSenderMock.On("PostDigests", mock.Anything,
+				mock.MatchedBy(func(body bclient.PostDigestsJSONRequestBody) bool {
+					return body.TgChatId == digest.ChatID && len(body.Links) == 1 &&
+						body.Links[0].Total == 1 && body.Links[0].Updates[0].Id == "42"
+				}), mock.Anything).
+				Return(&http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, tt.httpError)
+
+			if tt.expectKafka {
+				kafkaSenderMock.On("SendDigest", mock.Anything, digest).Return(tt.kafkaError)
+			}
+
+			upd := updater.New(httpSenderMock, kafkaSenderMock, config.HTTPTransport)
+
+			err := upd.SendDigest(ctx, digest)
 
 			if tt.expectedError {
 				assert.Error(t, err)
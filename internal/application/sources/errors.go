@@ -0,0 +1,13 @@
+package sources
+
+import "fmt"
+
+type sourcesErr struct{ msg string }
+
+func (e sourcesErr) Error() string { return fmt.Sprintf("error: %s", e.msg) }
+
+var (
+	ErrInvalidLink   = sourcesErr{msg: "link cannot be parsed"}
+	ErrUnknownSource = sourcesErr{msg: "unknown service"}
+	ErrInvalidPath   = sourcesErr{msg: "link does not point to a trackable resource"}
+)
@@ -0,0 +1,215 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+)
+
+// Source is one kind of trackable link. Implementations register themselves
+// from an init function, so the scrapper and the bot learn about a new source
+// by importing its package.
+type Source interface {
+	Name() string
+	// Match reports whether the url belongs to the source, judging by its host.
+	Match(u *url.URL) bool
+	// Validate rejects urls of the source that do not point to a trackable resource.
+	Validate(u *url.URL) error
+	// Canonical returns the single spelling a valid url is stored under.
+	Canonical(u *url.URL) string
+	NewClient(cfg *config.Config) Client
+}
+
+// Configurable sources read their settings, such as a self-hosted instance, before use.
+type Configurable interface {
+	Configure(cfg *config.Config) error
+}
+
+// Fallback sources accept links on any host, so they are consulted only
+// after every host-specific source has declined the link.
+type Fallback interface {
+	Fallback()
+}
+
+type Client interface {
+	RetrieveUpdates(ctx context.Context, link string, since time.Time) ([]models.Update, error)
+}
+
+// ScoredClient is a Client that also follows a score of the linked resource, such as the
+// votes on a question, and announces it crossing milestones between two polls. The scrapper
+// stores the returned score with the link and passes it back on the next poll, so
+// every replica compares with the same one. A nil score has not been observed yet.
+type ScoredClient interface {
+	RetrieveScoredUpdates(
+		ctx context.Context,
+		link string,
+		since time.Time,
+		score *int64,
+	) ([]models.Update, *int64, error)
+}
+
+var (
+	mu       sync.RWMutex
+	registry []Source
+)
+
+// Register makes a source available by name. It panics if the name is taken.
+func Register(source Source) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if slices.ContainsFunc(registry, func(s Source) bool { return s.Name() == source.Name() }) {
+		panic("sources: Register called twice for source " + source.Name())
+	}
+
+	registry = append(registry, source)
+}
+
+// Configure hands the configuration to every registered source that needs it.
+func Configure(cfg *config.Config) error {
+	for _, source := range All() {
+		configurable, ok := source.(Configurable)
+		if !ok {
+			continue
+		}
+
+		if err := configurable.Configure(cfg); err != nil {
+			return fmt.Errorf("sources: failed to configure %s: %w", source.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// All returns the registered sources in registration order.
+func All() []Source {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return slices.Clone(registry)
+}
+
+// Lookup finds the source a link belongs to and returns the parsed link.
+// A link typed without a scheme is treated as https.
+func Lookup(link string) (Source, *url.URL, error) {
+	link = strings.TrimSpace(link)
+	if !strings.Contains(link, "://") {
+		link = config.SchemeSecure + "://" + link
+	}
+
+	u, err := url.Parse(link)
+	if err != nil {
+		return nil, nil, ErrInvalidLink
+	}
+
+	registered := All()
+
+	for _, fallback := range []bool{false, true} {
+		for _, source := range registered {
+			if _, ok := source.(Fallback); ok != fallback {
+				continue
+			}
+
+			if source.Match(u) {
+				return source, u, nil
+			}
+		}
+	}
+
+	return nil, nil, ErrUnknownSource
+}
+
+// Name returns the name of the source a link belongs to.
+func Name(link string) (string, error) {
+	source, _, err := Lookup(link)
+	if err != nil {
+		return "", err
+	}
+
+	return source.Name(), nil
+}
+
+func Validate(link string) error {
+	source, u, err := Lookup(link)
+	if err != nil {
+		return err
+	}
+
+	return source.Validate(u)
+}
+
+// Canonical rewrites a link to the form it is stored under, so that
+// spellings of the same resource resolve to one row.
+func Canonical(link string) (string, error) {
+	source, u, err := Lookup(link)
+	if err != nil {
+		return "", err
+	}
+
+	if err := source.Validate(u); err != nil {
+		return "", err
+	}
+
+	return source.Canonical(u), nil
+}
+
+// Clients dispatches update retrieval to the client of the link's source.
+type Clients struct {
+	clients map[string]Client
+}
+
+// NewClients builds a client for every registered source.
+func NewClients(cfg *config.Config) *Clients {
+	registered := All()
+
+	clients := make(map[string]Client, len(registered))
+	for _, source := range registered {
+		clients[source.Name()] = source.NewClient(cfg)
+	}
+
+	return &Clients{clients: clients}
+}
+
+// RetrieveScoredUpdates returns the updates of the link and, for sources with a
+// ScoredClient, its current score. Other sources hand the score back unchanged.
+func (c *Clients) RetrieveScoredUpdates(
+	ctx context.Context,
+	link string,
+	since time.Time,
+	score *int64,
+) ([]models.Update, *int64, error) {
+	name, err := Name(link)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client, ok := c.clients[name]
+	if !ok {
+		return nil, nil, ErrUnknownSource
+	}
+
+	var updates []models.Update
+
+	if scored, ok := client.(ScoredClient); ok {
+		updates, score, err = scored.RetrieveScoredUpdates(ctx, link, since, score)
+	} else {
+		updates, err = client.RetrieveUpdates(ctx, link, since)
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i := range updates {
+		updates[i].Source = name
+	}
+
+	return updates, score, nil
+}
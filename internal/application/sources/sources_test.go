@@ -0,0 +1,230 @@
+package sources_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/sources"
+	_ "github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/sources/all"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/sources/github"
+	"github.com/stretchr/testify/require"
+)
+
+func TestName(t *testing.T) {
+	tests := map[string]struct {
+		link           string
+		expectedSource string
+		wantErr        bool
+	}{
+		"detected github": {
+			link:           "https://github.com/example/repo",
+			expectedSource: config.GitHub,
+			wantErr:        false,
+		},
+		"detected stackoverflow": {
+			link:           "https://stackoverflow.com/questions/1",
+			expectedSource: config.StackOverflow,
+			wantErr:        false,
+		},
+		"detected stackexchange site": {
+			link:           "https://superuser.com/questions/1",
+			expectedSource: config.StackOverflow,
+			wantErr:        false,
+		},
+		"detected gitlab": {
+			link:           "https://gitlab.com/group/project",
+			expectedSource: config.GitLab,
+			wantErr:        false,
+		},
+		"detected feed": {
+			link:           "https://go.dev/blog/feed.atom",
+			expectedSource: config.Feed,
+			wantErr:        false,
+		},
+		"feed on a known host": {
+			link:           "https://github.com/golang/go/releases.atom",
+			expectedSource: config.GitHub,
+			wantErr:        false,
+		},
+		"unknown service": {
+			link:           "https://youtube.com",
+			expectedSource: "",
+			wantErr:        true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actualSource, err := sources.Name(test.link)
+
+			if test.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, test.expectedSource, actualSource)
+		})
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	tests := map[string]struct {
+		link     string
+		expected string
+		wantErr  bool
+	}{
+		"already canonical": {
+			link:     "https://github.com/golang/go",
+			expected: "https://github.com/golang/go",
+		},
+		"github case and trailing slash": {
+			link:     "https://github.com/Golang/Go/",
+			expected: "https://github.com/golang/go",
+		},
+		"github www alias without scheme": {
+			link:     "www.github.com/golang/go",
+			expected: "https://github.com/golang/go",
+		},
+		"github query and fragment": {
+			link:     "http://github.com/golang/go?tab=readme#readme",
+			expected: "https://github.com/golang/go",
+		},
+		"github clone url": {
+			link:     "https://github.com/golang/go.git",
+			expected: "https://github.com/golang/go",
+		},
+		"github nested path keeps its case": {
+			link:     "https://GitHub.com/Golang/Go/tree/Main/",
+			expected: "https://github.com/golang/go/tree/Main",
+		},
+		"github branch path": {
+			link:     "https://github.com/Example/Monorepo/tree/main/deploy/",
+			expected: "https://github.com/example/monorepo/tree/main/deploy",
+		},
+		"github file on a branch": {
+			link:     "https://github.com/example/monorepo/blob/main/Makefile",
+			expected: "https://github.com/example/monorepo/tree/main/Makefile",
+		},
+		"github tree without branch": {
+			link:    "https://github.com/example/monorepo/tree",
+			wantErr: true,
+		},
+		"github pull request tab": {
+			link:     "https://github.com/Example/Repo/pull/42/files",
+			expected: "https://github.com/example/repo/pull/42",
+		},
+		"github pulls alias": {
+			link:     "https://github.com/example/repo/pulls/42",
+			expected: "https://github.com/example/repo/pull/42",
+		},
+		"github issue": {
+			link:     "https://github.com/example/repo/issues/7#issuecomment-1",
+			expected: "https://github.com/example/repo/issues/7",
+		},
+		"github issue without number": {
+			link:    "https://github.com/example/repo/issues/new",
+			wantErr: true,
+		},
+		"github workflow": {
+			link:     "https://github.com/Example/Repo/actions/workflows/ci.yml",
+			expected: "https://github.com/example/repo/actions/workflows/ci.yml",
+		},
+		"github workflow run": {
+			link:    "https://github.com/example/repo/actions/runs/123",
+			wantErr: true,
+		},
+		"github unsupported page": {
+			link:    "https://github.com/example/monorepo/wiki",
+			wantErr: true,
+		},
+		"stackoverflow slug": {
+			link:     "https://stackoverflow.com/questions/123/some-title",
+			expected: "https://stackoverflow.com/questions/123",
+		},
+		"stackoverflow short link": {
+			link:     "https://www.stackoverflow.com/q/123/456",
+			expected: "https://stackoverflow.com/questions/123",
+		},
+		"stackoverflow answer anchor": {
+			link:     "https://stackoverflow.com/questions/123/some-title?answertab=votes#456",
+			expected: "https://stackoverflow.com/questions/123",
+		},
+		"stackoverflow tag feed": {
+			link:     "https://stackoverflow.com/questions/tagged/PGX+go",
+			expected: "https://stackoverflow.com/questions/tagged/go+pgx",
+		},
+		"stackoverflow tag with escaped plus": {
+			link:     "https://stackoverflow.com/questions/tagged/c%2b%2b+c%23",
+			expected: "https://stackoverflow.com/questions/tagged/c%23+c%2B%2B",
+		},
+		"stackoverflow tag feed without tags": {
+			link:    "https://stackoverflow.com/questions/tagged/",
+			wantErr: true,
+		},
+		"serverfault question": {
+			link:     "https://www.ServerFault.com/questions/55/some-title",
+			expected: "https://serverfault.com/questions/55",
+		},
+		"stackexchange network site": {
+			link:     "https://math.stackexchange.com/q/77/1",
+			expected: "https://math.stackexchange.com/questions/77",
+		},
+		"localized stackoverflow": {
+			link:     "https://ru.stackoverflow.com/questions/tagged/go",
+			expected: "https://ru.stackoverflow.com/questions/tagged/go",
+		},
+		"stackexchange api host": {
+			link:    "https://api.stackexchange.com/questions/1",
+			wantErr: true,
+		},
+		"gitlab nested groups": {
+			link:     "https://GitLab.com/Group/SubGroup/Project.git",
+			expected: "https://gitlab.com/group/subgroup/project",
+		},
+		"gitlab project page keeps its path": {
+			link:     "https://gitlab.com/group/project/-/merge_requests/?scope=all",
+			expected: "https://gitlab.com/group/project/-/merge_requests",
+		},
+		"feed host and fragment": {
+			link:     "https://Go.Dev/blog/feed.atom#latest",
+			expected: "https://go.dev/blog/feed.atom",
+		},
+		"gitlab without project": {
+			link:    "https://gitlab.com/group",
+			wantErr: true,
+		},
+		"github without repository": {
+			link:    "https://github.com/golang",
+			wantErr: true,
+		},
+		"stackoverflow without question": {
+			link:    "https://stackoverflow.com/users/1",
+			wantErr: true,
+		},
+		"unknown service": {
+			link:    "https://youtube.com/watch",
+			wantErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, err := sources.Canonical(test.link)
+
+			if test.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestRegisterTwice(t *testing.T) {
+	require.Panics(t, func() {
+		sources.Register(github.Source{})
+	})
+}
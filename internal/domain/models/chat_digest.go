@@ -0,0 +1,27 @@
+package models
+
+// ChatDigest is everything a chat receives at its digest time, delivered as a single payload
+// instead of a message per update.
+type ChatDigest struct {
+	ChatID int64        `json:"chatId"`
+	Links  []LinkDigest `json:"links"`
+}
+
+// LinkDigest sums up the pending updates of one link: how many arrived of each kind
+// and the latest few of them.
+type LinkDigest struct {
+	URL     string      `json:"url"`
+	Total   int         `json:"total"`
+	Counts  []KindCount `json:"counts"`
+	Updates []Update    `json:"updates"`
+}
+
+type KindCount struct {
+	Kind  Kind `json:"kind"`
+	Count int  `json:"count"`
+}
+
+// More is the number of updates counted but left out of the digest.
+func (d LinkDigest) More() int {
+	return d.Total - len(d.Updates)
+}
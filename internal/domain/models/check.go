@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+const (
+	CheckIdle    = "idle"
+	CheckUpdated = "updated"
+	CheckFailed  = "failed"
+)
+
+// CheckResult is the outcome of a single fetch of a link
+// and the moment the link becomes due again. Score stays nil when the updates could
+// not be fetched and stored or the source follows no score, which keeps the stored one.
+type CheckResult struct {
+	Status      string
+	Error       string
+	Interval    time.Duration
+	NextCheckAt time.Time
+	Score       *int64
+}
+
+func NewCheckResult(saved int, err error) CheckResult {
+	result := CheckResult{
+		Status: CheckIdle,
+	}
+
+	switch {
+	case err != nil:
+		result.Status = CheckFailed
+		result.Error = err.Error()
+	case saved > 0:
+		result.Status = CheckUpdated
+	}
+
+	return result
+}
+
+func (r CheckResult) Active() bool {
+	return r.Status == CheckUpdated
+}
+
+// Schedule is what the fetcher needs to know to pick the next poll interval of a link,
+// along with the score the previous poll observed.
+type Schedule struct {
+	Interval    time.Duration
+	Subscribers int
+	Score       *int64
+}
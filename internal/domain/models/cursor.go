@@ -0,0 +1,82 @@
+package models
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Cursor marks the last update a subscriber has already seen.
+// The external ID breaks ties between updates published within the same second.
+// IDs are ordered by length first, so "comment:10" comes after "comment:9";
+// the repositories order rows the same way.
+type Cursor struct {
+	At time.Time
+	ID string
+}
+
+func NewCursor(at time.Time, id string) Cursor {
+	return Cursor{
+		At: at,
+		ID: id,
+	}
+}
+
+func (c Cursor) Precedes(at time.Time, id string) bool {
+	if at.Equal(c.At) {
+		return compareIDs(id, c.ID) > 0
+	}
+
+	return at.After(c.At)
+}
+
+func compareIDs(a, b string) int {
+	if len(a) != len(b) {
+		return cmp.Compare(len(a), len(b))
+	}
+
+	return strings.Compare(a, b)
+}
+
+// Sieve keeps updates published after the cursor, oldest first,
+// and returns the cursor advanced past all of them.
+func (c Cursor) Sieve(updates []Update) ([]Update, Cursor) {
+	type positioned struct {
+		update Update
+		cursor Cursor
+	}
+
+	fresh := make([]positioned, 0, len(updates))
+
+	for _, update := range updates {
+		if c.Precedes(update.CreatedAt, update.ID) {
+			fresh = append(fresh, positioned{
+				update: update,
+				cursor: NewCursor(update.CreatedAt, update.ID),
+			})
+		}
+	}
+
+	if len(fresh) == 0 {
+		return []Update{}, c
+	}
+
+	slices.SortFunc(fresh, func(a, b positioned) int {
+		switch {
+		case b.cursor.Precedes(a.cursor.At, a.cursor.ID):
+			return 1
+		case a.cursor.Precedes(b.cursor.At, b.cursor.ID):
+			return -1
+		}
+
+		return 0
+	})
+
+	sieved := make([]Update, 0, len(fresh))
+	for _, item := range fresh {
+		sieved = append(sieved, item.update)
+	}
+
+	return sieved, fresh[len(fresh)-1].cursor
+}
@@ -0,0 +1,105 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursorSieve(t *testing.T) {
+	at := time.Date(2025, 5, 16, 10, 0, 0, 0, time.UTC)
+
+	updates := []models.Update{
+		models.NewUpdate("3", models.KindIssue, "newest", "", at.Add(time.Hour), "", ""),
+		models.NewUpdate("2", models.KindIssue, "same second", "", at, "", ""),
+		models.NewUpdate("1", models.KindIssue, "seen", "", at, "", ""),
+		models.NewUpdate("0", models.KindIssue, "old", "", at.Add(-time.Hour), "", ""),
+	}
+
+	tests := map[string]struct {
+		cursor         models.Cursor
+		expectedTitles []string
+		expectedCursor models.Cursor
+	}{
+		"everything is new": {
+			cursor:         models.NewCursor(at.Add(-2*time.Hour), ""),
+			expectedTitles: []string{"old", "seen", "same second", "newest"},
+			expectedCursor: models.NewCursor(at.Add(time.Hour), "3"),
+		},
+		"tie broken by id": {
+			cursor:         models.NewCursor(at, "1"),
+			expectedTitles: []string{"same second", "newest"},
+			expectedCursor: models.NewCursor(at.Add(time.Hour), "3"),
+		},
+		"nothing new": {
+			cursor:         models.NewCursor(at.Add(time.Hour), "3"),
+			expectedTitles: []string{},
+			expectedCursor: models.NewCursor(at.Add(time.Hour), "3"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fresh, next := test.cursor.Sieve(updates)
+
+			titles := make([]string, 0, len(fresh))
+			for _, update := range fresh {
+				titles = append(titles, update.Title)
+			}
+
+			require.Equal(t, test.expectedTitles, titles)
+			require.True(t, test.expectedCursor.At.Equal(next.At))
+			require.Equal(t, test.expectedCursor.ID, next.ID)
+		})
+	}
+}
+
+func TestCursorPrecedesNumericIDs(t *testing.T) {
+	at := time.Date(2025, 5, 16, 10, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		cursor   models.Cursor
+		id       string
+		expected bool
+	}{
+		"longer id is later": {
+			cursor:   models.NewCursor(at, "comment:9"),
+			id:       "comment:10",
+			expected: true,
+		},
+		"shorter id is earlier": {
+			cursor:   models.NewCursor(at, "comment:10"),
+			id:       "comment:9",
+			expected: false,
+		},
+		"same length compares bytes": {
+			cursor:   models.NewCursor(at, "comment:10"),
+			id:       "comment:11",
+			expected: true,
+		},
+		"same id": {
+			cursor:   models.NewCursor(at, "comment:10"),
+			id:       "comment:10",
+			expected: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, test.expected, test.cursor.Precedes(at, test.id))
+		})
+	}
+
+	updates := []models.Update{
+		models.NewUpdate("comment:10", models.KindComment, "tenth", "", at, "", ""),
+		models.NewUpdate("comment:9", models.KindComment, "ninth", "", at, "", ""),
+	}
+
+	fresh, next := models.NewCursor(at, "comment:8").Sieve(updates)
+	require.Len(t, fresh, 2)
+	require.Equal(t, "ninth", fresh[0].Title)
+	require.Equal(t, "tenth", fresh[1].Title)
+	require.Equal(t, "comment:10", next.ID)
+}
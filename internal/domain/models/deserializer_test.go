@@ -14,19 +14,25 @@ func TestDeserialize(t *testing.T) {
 		wantErr bool
 	}{
 		"invalid chat id field name": {
-			update:  `{"chat_id": 123, "url": "http://example.com", "description": "something happened"}`,
+			update:  `{"chat_id": 123, "url": "http://example.com", "update": {"id": "1", "kind": "issue"}}`,
 			wantErr: true,
 		},
 		"invalid url field name": {
-			update:  `{"chatId": 123, "urll": "http://example.com", "description": "something happened"}`,
+			update:  `{"chatId": 123, "urll": "http://example.com", "update": {"id": "1", "kind": "issue"}}`,
 			wantErr: true,
 		},
-		"invalid description field name": {
-			update:  `{"chatId": 123, "url": "http://example.com", "deescription": "valid update"}`,
+		"pre-rendered description": {
+			update:  `{"chatId": 123, "url": "http://example.com", "description": "something happened"}`,
+			wantErr: true,
+		},
+		"invalid update timestamp": {
+			update:  `{"chatId": 123, "url": "http://example.com", "update": {"id": "1", "createdAt": "yesterday"}}`,
 			wantErr: true,
 		},
 		"valid update": {
-			update:  `{"chatId": 123, "url": "http://example.com", "description": "valid update"}`,
+			update: `{"chatId": 123, "url": "http://example.com", "update": {"id": "1", "source": "github",
+"kind": "issue", "title": "Crash", "url": "http://example.com/issues/1", "author": "gopher", "labels": ["bug"],
+"body": "panics on start", "createdAt": "2025-06-07T09:00:00Z", "updatedAt": "2025-06-07T09:30:00Z"}}`,
 			wantErr: false,
 		},
 	}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+const (
+	DefaultTimezone = "UTC"
+	DefaultSchedule = "10:00"
+)
+
+// Digest holds when a chat wants its pending updates delivered.
+// Schedule is either a comma-separated list of HH:MM times or a cron expression,
+// both read in the chat's timezone.
+type Digest struct {
+	ChatID   int64
+	Timezone string
+	Schedule string
+	SentAt   time.Time
+}
+
+func NewDigest(chatID int64, timezone, schedule string, sentAt time.Time) Digest {
+	return Digest{
+		ChatID:   chatID,
+		Timezone: timezone,
+		Schedule: schedule,
+		SentAt:   sentAt,
+	}
+}
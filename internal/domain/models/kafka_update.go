@@ -1,7 +1,10 @@
 package models
 
+// KafkaUpdate carries either a single update of a link or, when Digest is set,
+// the whole digest of the chat.
 type KafkaUpdate struct {
-	ChatID      int64  `json:"chatId"`
-	Url         string `json:"url"`
-	Description string `json:"description"`
+	ChatID int64       `json:"chatId"`
+	Url    string      `json:"url"`
+	Update Update      `json:"update"`
+	Digest *ChatDigest `json:"digest,omitempty"`
 }
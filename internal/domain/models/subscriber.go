@@ -0,0 +1,14 @@
+package models
+
+// Subscriber is a chat tracking a shared link together with its own filters.
+type Subscriber struct {
+	ChatID  int64
+	Filters []string
+}
+
+func NewSubscriber(chatID int64, filters ...string) Subscriber {
+	return Subscriber{
+		ChatID:  chatID,
+		Filters: filters,
+	}
+}
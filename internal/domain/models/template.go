@@ -0,0 +1,9 @@
+package models
+
+const (
+	// MaxTemplateLength bounds the text of a chat notification template, in runes.
+	// An empty template stands for the default layout.
+	MaxTemplateLength = 2048
+	// ResetTemplate is what a chat enters to go back to the default layout.
+	ResetTemplate = "default"
+)
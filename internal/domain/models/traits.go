@@ -44,6 +44,13 @@ func (t *Traits) HandleTrack(input string, link *sclient.AddLinkRequest) {
 	case 4:
 		filters := strings.Fields(input)
 		link.Filters = filters
+	case 5:
+		mode := sclient.Digest
+		if strings.ToLower(strings.TrimSpace(input)) == "yes" {
+			mode = sclient.Instant
+		}
+
+		link.Mode = &mode
 	}
 
 	t.Stage++
@@ -81,3 +88,34 @@ func (t *Traits) HandleList(input string, link *sclient.AddLinkRequest) {
 
 	t.Stage++
 }
+
+func (t *Traits) HandleSchedule(input string, digest *sclient.UpdateDigestRequest) {
+	t.Malformed = false
+
+	schedule := strings.TrimSpace(input)
+	digest.Schedule = &schedule
+
+	t.Stage++
+}
+
+func (t *Traits) HandleTimezone(input string, digest *sclient.UpdateDigestRequest) {
+	t.Malformed = false
+
+	timezone := strings.TrimSpace(input)
+	digest.Timezone = &timezone
+
+	t.Stage++
+}
+
+func (t *Traits) HandleTemplate(input string, template *sclient.UpdateTemplateRequest) {
+	t.Malformed = false
+
+	text := strings.TrimSpace(input)
+	if strings.EqualFold(text, ResetTemplate) {
+		text = ""
+	}
+
+	template.Template = text
+
+	t.Stage++
+}
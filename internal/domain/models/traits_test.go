@@ -69,6 +69,18 @@ func TestTraitsTrack(t *testing.T) {
 			expectedLink:  sclient.AddLinkRequest{Filters: []string{"filter1", "filter2"}},
 			expectedStage: 5,
 		},
+		"Stage 5 with 'yes' selects instant mode": {
+			initialStage:  5,
+			input:         "Yes",
+			expectedLink:  sclient.AddLinkRequest{Mode: ptr(sclient.Instant)},
+			expectedStage: 6,
+		},
+		"Stage 5 with 'no' keeps digest mode": {
+			initialStage:  5,
+			input:         "no",
+			expectedLink:  sclient.AddLinkRequest{Mode: ptr(sclient.Digest)},
+			expectedStage: 6,
+		},
 	}
 
 	for name, test := range tests {
@@ -181,3 +193,32 @@ func TestTraitsList(t *testing.T) {
 		})
 	}
 }
+
+func TestTraitsDigest(t *testing.T) {
+	traits := &models.Traits{}
+	digest := &sclient.UpdateDigestRequest{}
+
+	traits.HandleSchedule(" 09:00,18:30 ", digest)
+	traits.HandleTimezone("Europe/Moscow", digest)
+
+	require.Equal(t, "09:00,18:30", *digest.Schedule)
+	require.Equal(t, "Europe/Moscow", *digest.Timezone)
+	require.Equal(t, 2, traits.Stage)
+	require.Equal(t, false, traits.Malformed)
+}
+
+func TestTraitsTemplate(t *testing.T) {
+	traits := &models.Traits{}
+	template := &sclient.UpdateTemplateRequest{}
+
+	traits.HandleTemplate(" <b>{{.Title}}</b> ", template)
+	require.Equal(t, "<b>{{.Title}}</b>", template.Template)
+
+	traits.HandleTemplate("Default", template)
+	require.Empty(t, template.Template)
+	require.Equal(t, 2, traits.Stage)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
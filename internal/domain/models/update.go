@@ -1,33 +1,111 @@
 package models
 
 import (
-	"fmt"
 	"strings"
+	"time"
 )
 
+// Kind tells what happened on the tracked resource.
+type Kind string
+
+const (
+	KindIssue   Kind = "issue"
+	KindPR      Kind = "pr"
+	KindAnswer  Kind = "answer"
+	KindComment Kind = "comment"
+	KindTag     Kind = "tag"
+	KindPost    Kind = "post"
+	KindRelease Kind = "release"
+	KindCommit  Kind = "commit"
+
+	KindReview        Kind = "review"
+	KindReviewComment Kind = "review_comment"
+	KindLabel         Kind = "label"
+	KindState         Kind = "state"
+
+	KindWorkflow Kind = "workflow"
+	KindQuestion Kind = "question"
+
+	KindEdit       Kind = "edit"
+	KindAccepted   Kind = "accepted"
+	KindUnaccepted Kind = "unaccepted"
+	KindBounty     Kind = "bounty"
+	KindScore      Kind = "score"
+)
+
+// Releases and tags describe their version in labels, so filters can tell
+// a major release from a patch without the previous version at hand.
+const (
+	LabelMajor      = "semver:major"
+	LabelMinor      = "semver:minor"
+	LabelPatch      = "semver:patch"
+	LabelPrerelease = "semver:prerelease"
+)
+
+// Questions of a tag feed carry their score and whether they are still
+// unanswered, as "score:<n>" and "unanswered" labels next to their tags.
+// Score milestones carry the score label as well.
+const (
+	LabelScore      = "score:"
+	LabelUnanswered = "unanswered"
+)
+
+// excerptLength bounds the body kept with an update, in runes. It is kept
+// with its markup, renderers cut the visible text shorter.
+const excerptLength = 2000
+
+// Update is one event on a tracked link. ID is stable per link, so the same
+// event fetched twice is stored once. Source is filled in by the client registry.
 type Update struct {
-	Title     string
-	CreatedAt string
-	Author    string
-	Preview   string
+	ID        string    `json:"id"`
+	Source    string    `json:"source"`
+	Kind      Kind      `json:"kind"`
+	Title     string    `json:"title"`
+	URL       string    `json:"url"`
+	Author    string    `json:"author"`
+	Labels    []string  `json:"labels"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
 }
 
-func NewUpdate(title, createdAt, author, preview string) Update {
+// NewUpdate keeps an excerpt of the body only. The update counts as last changed
+// when it was created, sources that know better set UpdatedAt themselves.
+func NewUpdate(id string, kind Kind, title, url string, createdAt time.Time, author, body string, labels ...string) Update {
 	return Update{
+		ID:        id,
+		Kind:      kind,
 		Title:     title,
-		CreatedAt: createdAt,
+		URL:       url,
 		Author:    author,
-		Preview:   preview,
+		Labels:    labels,
+		Body:      Excerpt(body),
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
 	}
 }
 
-func (u *Update) String() string {
-	var b strings.Builder
+// Excerpt trims trailing spaces and runs of empty lines, which the markup of bodies
+// does not need, and cuts the text at excerptLength runes.
+func Excerpt(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	kept := make([]string, 0, len(lines))
+
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		if line == "" && len(kept) > 0 && kept[len(kept)-1] == "" {
+			continue
+		}
 
-	fmt.Fprintf(&b, "📌 New %s\n", u.Title)
-	fmt.Fprintf(&b, "🕒 Date: %s\n", u.CreatedAt)
-	fmt.Fprintf(&b, "👤 Author: %s\n", u.Author)
-	fmt.Fprintf(&b, "🔗 View: %s\n\n", u.Preview)
+		kept = append(kept, line)
+	}
+
+	text = strings.TrimSpace(strings.Join(kept, "\n"))
+
+	runes := []rune(text)
+	if len(runes) <= excerptLength {
+		return text
+	}
 
-	return b.String()
+	return string(runes[:excerptLength])
 }
@@ -0,0 +1,22 @@
+package models_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewUpdate(t *testing.T) {
+	at := time.Date(2025, 6, 7, 9, 0, 0, 0, time.UTC)
+	body := "Steps to reproduce:  \r\n\n\n\n  1. start the bot\n" + strings.Repeat("x", 2500)
+
+	update := models.NewUpdate("1", models.KindIssue, "Crash", "https://github.com/example/repo/issues/1",
+		at, "gopher", body)
+
+	require.Equal(t, at, update.UpdatedAt)
+	require.True(t, strings.HasPrefix(update.Body, "Steps to reproduce:\n\n  1. start the bot\nxxx"))
+	require.Len(t, []rune(update.Body), 2000)
+}
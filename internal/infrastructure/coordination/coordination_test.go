@@ -0,0 +1,154 @@
+package coordination_test
+
+import (
+	"context"
+	"log"
+	"net"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/coordination"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/repository/db"
+	"github.com/go-co-op/gocron/v2"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	tcredis "github.com/testcontainers/testcontainers-go/modules/redis"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+var (
+	rdb  *redis.Client
+	pool *pgxpool.Pool
+)
+
+func TestMain(m *testing.M) {
+	ctx := context.Background()
+
+	redisContainer, err := tcredis.Run(ctx, "redis:8")
+	defer func() {
+		if err := testcontainers.TerminateContainer(redisContainer); err != nil {
+			log.Printf("failed to terminate container: %s", err)
+		}
+	}()
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	host, err := redisContainer.Host(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container's host: %s", err)
+	}
+
+	port, err := redisContainer.MappedPort(ctx, "6379")
+	if err != nil {
+		log.Fatalf("failed to get container's port: %s", err)
+	}
+
+	rdb = redis.NewClient(&redis.Options{
+		Addr: net.JoinHostPort(host, strconv.Itoa(port.Int())),
+	})
+
+	pgContainer, err := postgres.Run(
+		ctx, "postgres:15-alpine",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("postgres"),
+		postgres.WithPassword("postgres"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(5*time.Second)),
+	)
+	defer func() {
+		if err := testcontainers.TerminateContainer(pgContainer); err != nil {
+			log.Printf("failed to terminate container: %s", err)
+		}
+	}()
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	host, err = pgContainer.Host(ctx)
+	if err != nil {
+		log.Fatalf("failed to get container's host: %s", err)
+	}
+
+	port, err = pgContainer.MappedPort(ctx, "5432")
+	if err != nil {
+		log.Fatalf("failed to get container's port: %s", err)
+	}
+
+	pool, err = db.New(config.Database{
+		Host:     host,
+		Port:     port.Int(),
+		Username: "postgres",
+		Password: "postgres",
+		Name:     "testdb",
+	})
+	if err != nil {
+		log.Fatalf("failed to initialize db: %s", err)
+	}
+	defer pool.Close()
+
+	os.Exit(m.Run())
+}
+
+func TestRedisLocker(t *testing.T) {
+	ctx := context.Background()
+
+	first := coordination.NewRedisLocker(rdb, time.Second)
+	second := coordination.NewRedisLocker(rdb, time.Second)
+
+	lock, err := first.Lock(ctx, "digest")
+	require.NoError(t, err)
+
+	_, err = second.Lock(ctx, "digest")
+	require.ErrorIs(t, err, coordination.ErrLockHeld)
+
+	require.NoError(t, lock.Unlock(ctx))
+
+	lock, err = second.Lock(ctx, "digest")
+	require.NoError(t, err)
+	require.NoError(t, lock.Unlock(ctx))
+}
+
+func TestRedisLockerStaleUnlock(t *testing.T) {
+	ctx := context.Background()
+
+	first := coordination.NewRedisLocker(rdb, 200*time.Millisecond)
+	second := coordination.NewRedisLocker(rdb, time.Second)
+
+	stale, err := first.Lock(ctx, "fetch")
+	require.NoError(t, err)
+
+	var current gocron.Lock
+
+	require.Eventually(t, func() bool {
+		current, err = second.Lock(ctx, "fetch")
+		return err == nil
+	}, 2*time.Second, 50*time.Millisecond)
+
+	// The expired lock must not release the one taken after it.
+	require.NoError(t, stale.Unlock(ctx))
+
+	_, err = first.Lock(ctx, "fetch")
+	require.ErrorIs(t, err, coordination.ErrLockHeld)
+
+	require.NoError(t, current.Unlock(ctx))
+}
+
+func TestPostgresElector(t *testing.T) {
+	ctx := context.Background()
+
+	leader := coordination.NewPostgresElector(pool, 42)
+	follower := coordination.NewPostgresElector(pool, 42)
+
+	require.NoError(t, leader.IsLeader(ctx))
+	require.NoError(t, leader.IsLeader(ctx))
+	require.ErrorIs(t, follower.IsLeader(ctx), coordination.ErrNotLeader)
+}
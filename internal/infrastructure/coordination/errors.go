@@ -0,0 +1,14 @@
+package coordination
+
+import (
+	"fmt"
+)
+
+type coordinationErr struct{ msg string }
+
+func (e coordinationErr) Error() string { return fmt.Sprintf("error: %s", e.msg) }
+
+var (
+	ErrLockHeld  = coordinationErr{msg: "lock is held by another instance"}
+	ErrNotLeader = coordinationErr{msg: "instance is not the leader"}
+)
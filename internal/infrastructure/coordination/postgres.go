@@ -0,0 +1,67 @@
+package coordination
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresElector elects a leader with a session-level advisory lock.
+// The leader keeps the connection that holds the lock; if it dies,
+// Postgres releases the lock and another instance takes over.
+type PostgresElector struct {
+	pool *pgxpool.Pool
+	key  int64
+
+	mu   sync.Mutex
+	conn *pgxpool.Conn
+}
+
+func NewPostgresElector(pool *pgxpool.Pool, key int64) *PostgresElector {
+	return &PostgresElector{
+		pool: pool,
+		key:  key,
+	}
+}
+
+func (e *PostgresElector) IsLeader(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.conn != nil {
+		if err := e.conn.Ping(ctx); err == nil {
+			return nil
+		}
+
+		e.release()
+	}
+
+	conn, err := e.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("elector: failed to acquire connection: %w", err)
+	}
+
+	var acquired bool
+	if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", e.key).Scan(&acquired); err != nil {
+		conn.Release()
+		return fmt.Errorf("elector: failed to try advisory lock: %w", err)
+	}
+
+	if !acquired {
+		conn.Release()
+		return ErrNotLeader
+	}
+
+	e.conn = conn
+
+	return nil
+}
+
+// release drops the connection instead of returning it to the pool,
+// so a lock held by a broken session is never handed to another caller.
+func (e *PostgresElector) release() {
+	_ = e.conn.Hijack().Close(context.Background())
+	e.conn = nil
+}
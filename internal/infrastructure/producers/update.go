@@ -26,18 +26,30 @@ func NewUpdatePublisher(producer service.Producer, serializer Serializer) *Updat
 	}
 }
 
-func (u *UpdatePublisher) Send(ctx context.Context, chatID int64, url, description string) error {
-	data, err := u.serializer.Serialize(models.KafkaUpdate{
-		ChatID:      chatID,
-		Url:         url,
-		Description: description,
+func (u *UpdatePublisher) Send(ctx context.Context, chatID int64, url string, update models.Update) error {
+	return u.publish(ctx, models.KafkaUpdate{
+		ChatID: chatID,
+		Url:    url,
+		Update: update,
 	})
+}
+
+func (u *UpdatePublisher) SendDigest(ctx context.Context, digest models.ChatDigest) error {
+	return u.publish(ctx, models.KafkaUpdate{
+		ChatID: digest.ChatID,
+		Digest: &digest,
+	})
+}
+
+// publish keys messages by chat, so the updates and digests of a chat keep their order.
+func (u *UpdatePublisher) publish(ctx context.Context, update models.KafkaUpdate) error {
+	data, err := u.serializer.Serialize(update)
 	if err != nil {
 		return fmt.Errorf("update publisher: failed to serialize update: %w", err)
 	}
 
 	msg := kafka.Message{
-		Key:   []byte(strconv.FormatInt(chatID, 10)),
+		Key:   []byte(strconv.FormatInt(update.ChatID, 10)),
 		Value: data,
 	}
 
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/es-debug/backend-academy-2024-go-template/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -13,6 +14,12 @@ type Repository interface {
 	Delete(ctx context.Context, chatID int64) error
 	ExistsID(ctx context.Context, chatID int64) error
 	GetIDs(ctx context.Context) ([]int64, error)
+	GetDigest(ctx context.Context, chatID int64) (models.Digest, error)
+	GetDigests(ctx context.Context) ([]models.Digest, error)
+	UpdateDigest(ctx context.Context, chatID int64, timezone, schedule string) error
+	ClaimDigest(ctx context.Context, chatID int64, prev, at time.Time) (bool, error)
+	GetTemplate(ctx context.Context, chatID int64) (string, error)
+	UpdateTemplate(ctx context.Context, chatID int64, template string) error
 }
 
 type Option func(Repository)
@@ -9,6 +9,7 @@ import (
 
 	sq "github.com/Masterminds/squirrel"
 	sapi "github.com/es-debug/backend-academy-2024-go-template/internal/api/openapi/v1/servers/scrapper"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/repository/txs"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -126,3 +127,155 @@ func (r *SquirrelRepository) GetIDs(ctx context.Context) ([]int64, error) {
 
 	return chatIDs, nil
 }
+
+func (r *SquirrelRepository) GetDigest(ctx context.Context, chatID int64) (models.Digest, error) {
+	query, args, err := r.sb.Select("id", "timezone", "digest_schedule", "digest_sent_at").
+		From("chats").
+		Where(sq.Eq{"id": chatID}).
+		ToSql()
+	if err != nil {
+		return models.Digest{}, fmt.Errorf("repo: failed to build select query: %w", err)
+	}
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	var digest models.Digest
+
+	if err := querier.QueryRow(ctx, query, args...).
+		Scan(&digest.ChatID, &digest.Timezone, &digest.Schedule, &digest.SentAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Digest{}, fmt.Errorf("repo: chat does not exist: %w", sapi.ErrChatNotExists)
+		}
+		return models.Digest{}, fmt.Errorf("repo: failed to select chat digest: %w", err)
+	}
+
+	return digest, nil
+}
+
+func (r *SquirrelRepository) GetDigests(ctx context.Context) ([]models.Digest, error) {
+	query, args, err := r.sb.Select("id", "timezone", "digest_schedule", "digest_sent_at").
+		From("chats").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("repo: failed to build select query: %w", err)
+	}
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	rows, err := querier.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("repo: failed to select chat digests: %w", err)
+	}
+	defer rows.Close()
+
+	digests := make([]models.Digest, 0)
+
+	for rows.Next() {
+		var digest models.Digest
+
+		if err := rows.Scan(&digest.ChatID, &digest.Timezone, &digest.Schedule, &digest.SentAt); err != nil {
+			return nil, fmt.Errorf("repo: failed to scan row: %w", err)
+		}
+
+		digests = append(digests, digest)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repo: failed to scan rows: %w", err)
+	}
+
+	return digests, nil
+}
+
+func (r *SquirrelRepository) UpdateDigest(ctx context.Context, chatID int64, timezone, schedule string) error {
+	query, args, err := r.sb.Update("chats").
+		Set("timezone", timezone).
+		Set("digest_schedule", schedule).
+		Set("updated_at", r.now()).
+		Where(sq.Eq{"id": chatID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("repo: failed to build update query: %w", err)
+	}
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	result, err := querier.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("repo: failed to update chat digest: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("repo: %w", sapi.ErrChatNotExists)
+	}
+
+	return nil
+}
+
+// ClaimDigest moves the chat's digest_sent_at from prev to at. It reports false when
+// another replica has moved it first, so only one of them sends the digest.
+func (r *SquirrelRepository) ClaimDigest(ctx context.Context, chatID int64, prev, at time.Time) (bool, error) {
+	query, args, err := r.sb.Update("chats").
+		Set("digest_sent_at", at).
+		Where(sq.Eq{"id": chatID, "digest_sent_at": prev}).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("repo: failed to build update query: %w", err)
+	}
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	result, err := querier.Exec(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("repo: failed to claim chat digest: %w", err)
+	}
+
+	return result.RowsAffected() == 1, nil
+}
+
+func (r *SquirrelRepository) GetTemplate(ctx context.Context, chatID int64) (string, error) {
+	query, args, err := r.sb.Select("template").
+		From("chats").
+		Where(sq.Eq{"id": chatID}).
+		ToSql()
+	if err != nil {
+		return "", fmt.Errorf("repo: failed to build select query: %w", err)
+	}
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	var template string
+
+	if err := querier.QueryRow(ctx, query, args...).Scan(&template); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("repo: chat does not exist: %w", sapi.ErrChatNotExists)
+		}
+		return "", fmt.Errorf("repo: failed to select chat template: %w", err)
+	}
+
+	return template, nil
+}
+
+func (r *SquirrelRepository) UpdateTemplate(ctx context.Context, chatID int64, template string) error {
+	query, args, err := r.sb.Update("chats").
+		Set("template", template).
+		Set("updated_at", r.now()).
+		Where(sq.Eq{"id": chatID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("repo: failed to build update query: %w", err)
+	}
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	result, err := querier.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("repo: failed to update chat template: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("repo: %w", sapi.ErrChatNotExists)
+	}
+
+	return nil
+}
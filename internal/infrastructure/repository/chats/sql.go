@@ -8,6 +8,7 @@ import (
 	"time"
 
 	sapi "github.com/es-debug/backend-academy-2024-go-template/internal/api/openapi/v1/servers/scrapper"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/repository/txs"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -102,3 +103,117 @@ func (r *SQLRepository) GetIDs(ctx context.Context) ([]int64, error) {
 
 	return chatIDs, nil
 }
+
+func (r *SQLRepository) GetDigest(ctx context.Context, chatID int64) (models.Digest, error) {
+	const query = "SELECT id, timezone, digest_schedule, digest_sent_at FROM chats WHERE id = $1"
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	var digest models.Digest
+
+	if err := querier.QueryRow(ctx, query, chatID).
+		Scan(&digest.ChatID, &digest.Timezone, &digest.Schedule, &digest.SentAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Digest{}, fmt.Errorf("repo: chat does not exist: %w", sapi.ErrChatNotExists)
+		}
+		return models.Digest{}, fmt.Errorf("repo: failed to select chat digest: %w", err)
+	}
+
+	return digest, nil
+}
+
+func (r *SQLRepository) GetDigests(ctx context.Context) ([]models.Digest, error) {
+	const query = "SELECT id, timezone, digest_schedule, digest_sent_at FROM chats"
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	rows, err := querier.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("repo: failed to select chat digests: %w", err)
+	}
+	defer rows.Close()
+
+	digests := make([]models.Digest, 0)
+
+	for rows.Next() {
+		var digest models.Digest
+
+		if err := rows.Scan(&digest.ChatID, &digest.Timezone, &digest.Schedule, &digest.SentAt); err != nil {
+			return nil, fmt.Errorf("repo: failed to scan row: %w", err)
+		}
+
+		digests = append(digests, digest)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repo: failed to scan rows: %w", err)
+	}
+
+	return digests, nil
+}
+
+func (r *SQLRepository) UpdateDigest(ctx context.Context, chatID int64, timezone, schedule string) error {
+	const query = "UPDATE chats SET timezone = $1, digest_schedule = $2, updated_at = $3 WHERE id = $4"
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	result, err := querier.Exec(ctx, query, timezone, schedule, r.now(), chatID)
+	if err != nil {
+		return fmt.Errorf("repo: failed to update chat digest: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("repo: %w", sapi.ErrChatNotExists)
+	}
+
+	return nil
+}
+
+// ClaimDigest moves the chat's digest_sent_at from prev to at. It reports false when
+// another replica has moved it first, so only one of them sends the digest.
+func (r *SQLRepository) ClaimDigest(ctx context.Context, chatID int64, prev, at time.Time) (bool, error) {
+	const query = "UPDATE chats SET digest_sent_at = $1 WHERE id = $2 AND digest_sent_at = $3"
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	result, err := querier.Exec(ctx, query, at, chatID, prev)
+	if err != nil {
+		return false, fmt.Errorf("repo: failed to claim chat digest: %w", err)
+	}
+
+	return result.RowsAffected() == 1, nil
+}
+
+func (r *SQLRepository) GetTemplate(ctx context.Context, chatID int64) (string, error) {
+	const query = "SELECT template FROM chats WHERE id = $1"
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	var template string
+
+	if err := querier.QueryRow(ctx, query, chatID).Scan(&template); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("repo: chat does not exist: %w", sapi.ErrChatNotExists)
+		}
+		return "", fmt.Errorf("repo: failed to select chat template: %w", err)
+	}
+
+	return template, nil
+}
+
+func (r *SQLRepository) UpdateTemplate(ctx context.Context, chatID int64, template string) error {
+	const query = "UPDATE chats SET template = $1, updated_at = $2 WHERE id = $3"
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	result, err := querier.Exec(ctx, query, template, r.now(), chatID)
+	if err != nil {
+		return fmt.Errorf("repo: failed to update chat template: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("repo: %w", sapi.ErrChatNotExists)
+	}
+
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/es-debug/backend-academy-2024-go-template/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/repository/chats"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/repository/db"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/repository/links"
@@ -104,6 +105,52 @@ func TestChatsRepository(t *testing.T) {
 				}
 			})
 
+			t.Run("update digest", func(t *testing.T) {
+				digest, err := repo.GetDigest(ctx, chatIDs[0])
+				require.NoError(t, err)
+				require.Equal(t, models.DefaultTimezone, digest.Timezone)
+				require.Equal(t, models.DefaultSchedule, digest.Schedule)
+
+				require.NoError(t, repo.UpdateDigest(ctx, chatIDs[0], "Asia/Tokyo", "09:00,18:30"))
+
+				sentAt := time.Date(2025, 6, 4, 9, 0, 0, 0, time.UTC)
+				claimed, err := repo.ClaimDigest(ctx, chatIDs[0], digest.SentAt, sentAt)
+				require.NoError(t, err)
+				require.True(t, claimed)
+
+				claimed, err = repo.ClaimDigest(ctx, chatIDs[0], digest.SentAt, sentAt.Add(time.Hour))
+				require.NoError(t, err)
+				require.False(t, claimed)
+
+				digest, err = repo.GetDigest(ctx, chatIDs[0])
+				require.NoError(t, err)
+				require.Equal(t, "Asia/Tokyo", digest.Timezone)
+				require.Equal(t, "09:00,18:30", digest.Schedule)
+				require.True(t, sentAt.Equal(digest.SentAt))
+
+				digests, err := repo.GetDigests(ctx)
+				require.NoError(t, err)
+				require.Len(t, digests, len(chatIDs))
+
+				require.Error(t, repo.UpdateDigest(ctx, 404, "UTC", "10:00"))
+			})
+
+			t.Run("update template", func(t *testing.T) {
+				template, err := repo.GetTemplate(ctx, chatIDs[0])
+				require.NoError(t, err)
+				require.Empty(t, template)
+
+				require.NoError(t, repo.UpdateTemplate(ctx, chatIDs[0], "{{.Title}} {{.URL}}"))
+
+				template, err = repo.GetTemplate(ctx, chatIDs[0])
+				require.NoError(t, err)
+				require.Equal(t, "{{.Title}} {{.URL}}", template)
+
+				_, err = repo.GetTemplate(ctx, 404)
+				require.Error(t, err)
+				require.Error(t, repo.UpdateTemplate(ctx, 404, ""))
+			})
+
 			t.Run("delete chats", func(t *testing.T) {
 				retrievedIDs, err := repo.GetIDs(ctx)
 				require.NoError(t, err)
@@ -163,6 +210,15 @@ func TestLinksRepository(t *testing.T) {
 				require.Equal(t, len(urls), len(linkIDs))
 			})
 
+			t.Run("add existing link", func(t *testing.T) {
+				first, err := repo.Add(ctx, urls[0])
+				require.NoError(t, err)
+
+				second, err := repo.Add(ctx, urls[0])
+				require.NoError(t, err)
+				require.Equal(t, first, second)
+			})
+
 			t.Run("get batch", func(t *testing.T) {
 				batch, err := repo.GetBatch(ctx, uint64(len(urls)))
 				require.NoError(t, err)
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+BEGIN;
+
+ALTER TABLE subs
+    ADD COLUMN IF NOT EXISTS cursor_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN IF NOT EXISTS cursor_id TEXT NOT NULL DEFAULT '';
+
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+BEGIN;
+
+ALTER TABLE subs
+    DROP COLUMN IF EXISTS cursor_at,
+    DROP COLUMN IF EXISTS cursor_id;
+
+END;
+-- +goose StatementEnd
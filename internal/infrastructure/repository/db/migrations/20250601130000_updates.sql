@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS updates (
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    link_id BIGINT NOT NULL REFERENCES links (id) ON DELETE CASCADE,
+    external_id TEXT COLLATE "C" NOT NULL,
+    kind TEXT NOT NULL,
+    title TEXT NOT NULL DEFAULT '',
+    author TEXT NOT NULL DEFAULT '',
+    url TEXT NOT NULL DEFAULT '',
+    body TEXT NOT NULL DEFAULT '',
+    labels TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL,
+    fetched_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (link_id, external_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_updates_link_created ON updates (link_id, created_at, external_id);
+
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+BEGIN;
+
+DROP INDEX IF EXISTS idx_updates_link_created;
+DROP TABLE IF EXISTS updates;
+
+END;
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+BEGIN;
+
+ALTER TABLE links
+    ADD COLUMN IF NOT EXISTS next_check_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN IF NOT EXISTS last_checked_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS last_check_status TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS last_check_error TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_links_next_check_at ON links (next_check_at);
+
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+BEGIN;
+
+DROP INDEX IF EXISTS idx_links_next_check_at;
+
+ALTER TABLE links
+    DROP COLUMN IF EXISTS next_check_at,
+    DROP COLUMN IF EXISTS last_checked_at,
+    DROP COLUMN IF EXISTS last_check_status,
+    DROP COLUMN IF EXISTS last_check_error;
+
+END;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+BEGIN;
+
+ALTER TABLE chats ADD COLUMN IF NOT EXISTS timezone TEXT NOT NULL DEFAULT 'UTC';
+ALTER TABLE chats ADD COLUMN IF NOT EXISTS digest_schedule TEXT NOT NULL DEFAULT '10:00';
+ALTER TABLE chats ADD COLUMN IF NOT EXISTS digest_sent_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+BEGIN;
+
+ALTER TABLE chats DROP COLUMN IF EXISTS digest_sent_at;
+ALTER TABLE chats DROP COLUMN IF EXISTS digest_schedule;
+ALTER TABLE chats DROP COLUMN IF EXISTS timezone;
+
+END;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE subs ADD COLUMN IF NOT EXISTS delivery_mode TEXT NOT NULL DEFAULT 'digest'
+    CHECK (delivery_mode IN ('instant', 'digest'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE subs DROP COLUMN IF EXISTS delivery_mode;
+-- +goose StatementEnd
@@ -0,0 +1,88 @@
+-- +goose Up
+-- +goose StatementBegin
+BEGIN;
+
+-- Tags and filters belong to a subscription rather than to a link.
+ALTER TABLE tags ADD COLUMN IF NOT EXISTS chat_id BIGINT;
+ALTER TABLE filters ADD COLUMN IF NOT EXISTS chat_id BIGINT;
+
+UPDATE tags t SET chat_id = s.chat_id FROM subs s WHERE s.link_id = t.link_id;
+UPDATE filters f SET chat_id = s.chat_id FROM subs s WHERE s.link_id = f.link_id;
+
+-- Every url keeps its oldest row, the rest are folded into it.
+CREATE TEMPORARY TABLE link_merge ON COMMIT DROP AS
+SELECT id AS old_id, MIN(id) OVER (PARTITION BY url) AS new_id FROM links;
+
+DELETE FROM link_merge WHERE old_id = new_id;
+
+UPDATE links l SET
+    next_check_at = g.next_check_at,
+    check_interval_ms = g.check_interval_ms,
+    is_active = g.is_active,
+    leased_until = NULL
+FROM (
+    SELECT MIN(id) AS id, MIN(next_check_at) AS next_check_at,
+        MIN(check_interval_ms) AS check_interval_ms, BOOL_OR(is_active) AS is_active
+    FROM links GROUP BY url HAVING COUNT(*) > 1
+) g
+WHERE l.id = g.id;
+
+INSERT INTO updates (link_id, external_id, kind, title, author, url, body, labels, created_at, fetched_at)
+SELECT m.new_id, u.external_id, u.kind, u.title, u.author, u.url, u.body, u.labels, u.created_at, u.fetched_at
+FROM updates u JOIN link_merge m ON m.old_id = u.link_id
+ON CONFLICT (link_id, external_id) DO NOTHING;
+
+-- A chat subscribed to several copies of the same url keeps the oldest subscription.
+DELETE FROM subs s USING link_merge m
+WHERE s.link_id = m.old_id AND EXISTS (
+    SELECT 1 FROM subs k LEFT JOIN link_merge km ON km.old_id = k.link_id
+    WHERE k.chat_id = s.chat_id AND COALESCE(km.new_id, k.link_id) = m.new_id AND k.link_id < s.link_id
+);
+
+DELETE FROM tags t WHERE NOT EXISTS (SELECT 1 FROM subs s WHERE s.chat_id = t.chat_id AND s.link_id = t.link_id);
+DELETE FROM filters f WHERE NOT EXISTS (SELECT 1 FROM subs s WHERE s.chat_id = f.chat_id AND s.link_id = f.link_id);
+
+UPDATE subs s SET link_id = m.new_id FROM link_merge m WHERE s.link_id = m.old_id;
+UPDATE tags t SET link_id = m.new_id FROM link_merge m WHERE t.link_id = m.old_id;
+UPDATE filters f SET link_id = m.new_id FROM link_merge m WHERE f.link_id = m.old_id;
+
+DELETE FROM links l USING link_merge m WHERE l.id = m.old_id;
+
+DROP INDEX IF EXISTS idx_links_url;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_links_url ON links (url);
+
+ALTER TABLE tags ALTER COLUMN chat_id SET NOT NULL;
+ALTER TABLE tags ALTER COLUMN link_id SET NOT NULL;
+ALTER TABLE tags ADD CONSTRAINT fk_tags_subs FOREIGN KEY (chat_id, link_id)
+    REFERENCES subs (chat_id, link_id) ON DELETE CASCADE;
+
+ALTER TABLE filters ALTER COLUMN chat_id SET NOT NULL;
+ALTER TABLE filters ALTER COLUMN link_id SET NOT NULL;
+ALTER TABLE filters ADD CONSTRAINT fk_filters_subs FOREIGN KEY (chat_id, link_id)
+    REFERENCES subs (chat_id, link_id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_tags_sub ON tags (chat_id, link_id);
+CREATE INDEX IF NOT EXISTS idx_filters_sub ON filters (chat_id, link_id);
+
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+BEGIN;
+
+-- Merged links are not split back: subscribers of the same url keep sharing one row.
+DROP INDEX IF EXISTS idx_filters_sub;
+DROP INDEX IF EXISTS idx_tags_sub;
+
+ALTER TABLE filters DROP CONSTRAINT IF EXISTS fk_filters_subs;
+ALTER TABLE filters DROP COLUMN IF EXISTS chat_id;
+
+ALTER TABLE tags DROP CONSTRAINT IF EXISTS fk_tags_subs;
+ALTER TABLE tags DROP COLUMN IF EXISTS chat_id;
+
+DROP INDEX IF EXISTS idx_links_url;
+CREATE INDEX IF NOT EXISTS idx_links_url ON links USING HASH (url);
+
+END;
+-- +goose StatementEnd
@@ -0,0 +1,77 @@
+-- +goose Up
+-- +goose StatementBegin
+BEGIN;
+
+-- A frozen snapshot of the canonical GitHub and StackOverflow urls as sources.Canonical
+-- produced them when canonicalisation was introduced, applied to the urls stored before it.
+CREATE TEMPORARY TABLE link_canon ON COMMIT DROP AS
+SELECT id, CASE
+    WHEN url ~* '^(https?://)?(www\.)?github\.com/[^/]+/[^/]+' THEN
+        'https://github.com/'
+        || lower((regexp_match(url, '^(?:https?://)?(?:www\.)?github\.com/([^/]+)/([^/]+)(/.*)?$', 'i'))[1]) || '/'
+        || lower(regexp_replace((regexp_match(url, '^(?:https?://)?(?:www\.)?github\.com/([^/]+)/([^/]+)(/.*)?$', 'i'))[2], '\.git$', ''))
+        || COALESCE((regexp_match(url, '^(?:https?://)?(?:www\.)?github\.com/([^/]+)/([^/]+)(/.*)?$', 'i'))[3], '')
+    WHEN url ~* '^(https?://)?(www\.|m\.)?stackoverflow\.com/(questions|q)/[0-9]+' THEN
+        regexp_replace(url, '^(?:https?://)?(?:www\.|m\.)?stackoverflow\.com/(?:questions|q)/([0-9]+).*$',
+            'https://stackoverflow.com/questions/\1', 'i')
+    ELSE url
+END AS url
+FROM (SELECT id, regexp_replace(regexp_replace(url, '[?#].*$', ''), '/+$', '') AS url FROM links) stripped;
+
+-- Links that collapse onto the same canonical url are folded into the oldest one.
+CREATE TEMPORARY TABLE link_merge ON COMMIT DROP AS
+SELECT id AS old_id, MIN(id) OVER (PARTITION BY url) AS new_id FROM link_canon;
+
+DELETE FROM link_merge WHERE old_id = new_id;
+
+ALTER TABLE tags DROP CONSTRAINT IF EXISTS fk_tags_subs;
+ALTER TABLE filters DROP CONSTRAINT IF EXISTS fk_filters_subs;
+
+UPDATE links l SET
+    next_check_at = g.next_check_at,
+    check_interval_ms = g.check_interval_ms,
+    is_active = g.is_active,
+    leased_until = NULL
+FROM (
+    SELECT MIN(c.id) AS id, MIN(k.next_check_at) AS next_check_at,
+        MIN(k.check_interval_ms) AS check_interval_ms, BOOL_OR(k.is_active) AS is_active
+    FROM link_canon c JOIN links k ON k.id = c.id
+    GROUP BY c.url HAVING COUNT(*) > 1
+) g
+WHERE l.id = g.id;
+
+INSERT INTO updates (link_id, external_id, kind, title, author, url, body, labels, created_at, fetched_at)
+SELECT m.new_id, u.external_id, u.kind, u.title, u.author, u.url, u.body, u.labels, u.created_at, u.fetched_at
+FROM updates u JOIN link_merge m ON m.old_id = u.link_id
+ON CONFLICT (link_id, external_id) DO NOTHING;
+
+DELETE FROM subs s USING link_merge m
+WHERE s.link_id = m.old_id AND EXISTS (
+    SELECT 1 FROM subs k LEFT JOIN link_merge km ON km.old_id = k.link_id
+    WHERE k.chat_id = s.chat_id AND COALESCE(km.new_id, k.link_id) = m.new_id AND k.link_id < s.link_id
+);
+
+DELETE FROM tags t WHERE NOT EXISTS (SELECT 1 FROM subs s WHERE s.chat_id = t.chat_id AND s.link_id = t.link_id);
+DELETE FROM filters f WHERE NOT EXISTS (SELECT 1 FROM subs s WHERE s.chat_id = f.chat_id AND s.link_id = f.link_id);
+
+UPDATE subs s SET link_id = m.new_id FROM link_merge m WHERE s.link_id = m.old_id;
+UPDATE tags t SET link_id = m.new_id FROM link_merge m WHERE t.link_id = m.old_id;
+UPDATE filters f SET link_id = m.new_id FROM link_merge m WHERE f.link_id = m.old_id;
+
+DELETE FROM links l USING link_merge m WHERE l.id = m.old_id;
+
+UPDATE links l SET url = c.url FROM link_canon c WHERE l.id = c.id AND l.url <> c.url;
+
+ALTER TABLE tags ADD CONSTRAINT fk_tags_subs FOREIGN KEY (chat_id, link_id)
+    REFERENCES subs (chat_id, link_id) ON DELETE CASCADE;
+ALTER TABLE filters ADD CONSTRAINT fk_filters_subs FOREIGN KEY (chat_id, link_id)
+    REFERENCES subs (chat_id, link_id) ON DELETE CASCADE;
+
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- The original spellings are not kept, so rewritten urls stay canonical.
+SELECT 1;
+-- +goose StatementEnd
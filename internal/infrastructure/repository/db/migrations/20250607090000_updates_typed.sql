@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+BEGIN;
+
+ALTER TABLE updates ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT '';
+ALTER TABLE updates ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ;
+
+UPDATE updates SET updated_at = created_at WHERE updated_at IS NULL;
+
+ALTER TABLE updates ALTER COLUMN updated_at SET NOT NULL;
+
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+BEGIN;
+
+ALTER TABLE updates DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE updates DROP COLUMN IF EXISTS source;
+
+END;
+-- +goose StatementEnd
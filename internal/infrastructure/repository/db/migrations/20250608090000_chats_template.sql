@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+BEGIN;
+
+ALTER TABLE chats ADD COLUMN IF NOT EXISTS template TEXT NOT NULL DEFAULT '';
+
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+BEGIN;
+
+ALTER TABLE chats DROP COLUMN IF EXISTS template;
+
+END;
+-- +goose StatementEnd
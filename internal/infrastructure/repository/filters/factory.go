@@ -9,7 +9,7 @@ import (
 )
 
 type Repository interface {
-	Add(ctx context.Context, filter string, linkID int64) error
+	Add(ctx context.Context, filter string, chatID, linkID int64) error
 }
 
 type Option func(Repository)
@@ -25,10 +25,10 @@ func NewSquirrelRepository(pool *pgxpool.Pool) *SquirrelRepository {
 	}
 }
 
-func (r *SquirrelRepository) Add(ctx context.Context, filter string, linkID int64) error {
+func (r *SquirrelRepository) Add(ctx context.Context, filter string, chatID, linkID int64) error {
 	sql, args, err := r.sb.Insert("filters").
-		Columns("filter_value", "link_id").
-		Values(filter, linkID).
+		Columns("filter_value", "chat_id", "link_id").
+		Values(filter, chatID, linkID).
 		ToSql()
 	if err != nil {
 		return fmt.Errorf("repo: failed to build insert query: %w", err)
@@ -22,11 +22,11 @@ func NewSQLRepository(pool *pgxpool.Pool) *SQLRepository {
 	}
 }
 
-func (r *SQLRepository) Add(ctx context.Context, filter string, linkID int64) error {
-	const query = "INSERT INTO filters (filter_value, link_id) VALUES ($1, $2)"
+func (r *SQLRepository) Add(ctx context.Context, filter string, chatID, linkID int64) error {
+	const query = "INSERT INTO filters (filter_value, chat_id, link_id) VALUES ($1, $2, $3)"
 	querier := txs.GetQuerier(ctx, r.db)
 
-	result, err := querier.Exec(ctx, query, filter, linkID)
+	result, err := querier.Exec(ctx, query, filter, chatID, linkID)
 	if err != nil {
 		return fmt.Errorf("repo: failed to insert filter: %w", err)
 	}
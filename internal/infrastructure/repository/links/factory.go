@@ -6,14 +6,19 @@ import (
 
 	"github.com/es-debug/backend-academy-2024-go-template/config"
 	sapi "github.com/es-debug/backend-academy-2024-go-template/internal/api/openapi/v1/servers/scrapper"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type Repository interface {
 	Add(ctx context.Context, url string) (int64, error)
 	Delete(ctx context.Context, linkID int64) error
+	DeleteUnused(ctx context.Context, linkID int64) error
 	Touch(ctx context.Context, linkID int64) error
 	UpdateActivity(ctx context.Context, linkID int64, status bool) error
+	MarkChecked(ctx context.Context, linkID int64, result models.CheckResult) error
+	GetSchedule(ctx context.Context, linkID int64) (models.Schedule, error)
+	Lease(ctx context.Context, linkIDs []int64, until time.Time) error
 	GetBatch(ctx context.Context, batchSize uint64) ([]sapi.LinkResponse, error)
 }
 
@@ -2,13 +2,15 @@ package links
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	sapi "github.com/es-debug/backend-academy-2024-go-template/internal/api/openapi/v1/servers/scrapper"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/repository/txs"
-	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -30,7 +32,7 @@ func (r *SquirrelRepository) Add(ctx context.Context, url string) (int64, error)
 	query, args, err := r.sb.Insert("links").
 		Columns("url").
 		Values(url).
-		Suffix("RETURNING id").
+		Suffix("ON CONFLICT (url) DO UPDATE SET url = EXCLUDED.url RETURNING id").
 		ToSql()
 	if err != nil {
 		return 0, fmt.Errorf("repo: failed to build insert query: %w", err)
@@ -70,6 +72,24 @@ func (r *SquirrelRepository) Delete(ctx context.Context, linkID int64) error {
 	return nil
 }
 
+func (r *SquirrelRepository) DeleteUnused(ctx context.Context, linkID int64) error {
+	query, args, err := r.sb.Delete("links").
+		Where(sq.Eq{"id": linkID}).
+		Where("NOT EXISTS (SELECT 1 FROM subs s WHERE s.link_id = ?)", linkID).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("repo: failed to build delete query: %w", err)
+	}
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	if _, err := querier.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("repo: failed to delete unused link: %w", err)
+	}
+
+	return nil
+}
+
 func (r *SquirrelRepository) Touch(ctx context.Context, linkID int64) error {
 	query, args, err := r.sb.Update("links").
 		Set("updated_at", time.Now()).
@@ -116,16 +136,98 @@ func (r *SquirrelRepository) UpdateActivity(ctx context.Context, linkID int64, s
 	return nil
 }
 
-func (r *SquirrelRepository) GetBatch(ctx context.Context, batch uint64) ([]sapi.LinkResponse, error) {
+func (r *SquirrelRepository) MarkChecked(ctx context.Context, linkID int64, result models.CheckResult) error {
+	query, args, err := r.sb.Update("links").
+		Set("last_checked_at", r.now()).
+		Set("next_check_at", result.NextCheckAt).
+		Set("last_check_status", result.Status).
+		Set("last_check_error", result.Error).
+		Set("check_interval_ms", result.Interval.Milliseconds()).
+		Set("score", sq.Expr("COALESCE(?, score)", result.Score)).
+		Set("leased_until", nil).
+		Where(sq.Eq{"id": linkID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("repo: failed to build update query: %w", err)
+	}
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	tag, err := querier.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("repo: failed to mark link checked: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return sapi.ErrLinkNotExists
+	}
+
+	return nil
+}
+
+func (r *SquirrelRepository) GetSchedule(ctx context.Context, linkID int64) (models.Schedule, error) {
 	query, args, err := r.sb.Select(
-		"l.id",
-		"l.url",
-		"COALESCE((SELECT ARRAY_AGG(t.tag) FROM tags t WHERE t.link_id = l.id), '{}') AS tags",
-		"COALESCE((SELECT ARRAY_AGG(f.filter_value) FROM filters f WHERE f.link_id = l.id), '{}') AS filters",
+		"l.check_interval_ms",
+		"(SELECT COUNT(*) FROM subs s WHERE s.link_id = l.id)",
+		"l.score",
 	).
 		From("links l").
-		OrderBy("l.updated_at").
+		Where(sq.Eq{"l.id": linkID}).
+		ToSql()
+	if err != nil {
+		return models.Schedule{}, fmt.Errorf("repo: failed to build select query: %w", err)
+	}
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	var (
+		intervalMs, subscribers int64
+		score                   *int64
+	)
+
+	if err := querier.QueryRow(ctx, query, args...).Scan(&intervalMs, &subscribers, &score); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Schedule{}, sapi.ErrLinkNotExists
+		}
+		return models.Schedule{}, fmt.Errorf("repo: failed to select link schedule: %w", err)
+	}
+
+	return models.Schedule{
+		Interval:    time.Duration(intervalMs) * time.Millisecond,
+		Subscribers: int(subscribers),
+		Score:       score,
+	}, nil
+}
+
+func (r *SquirrelRepository) Lease(ctx context.Context, linkIDs []int64, until time.Time) error {
+	query, args, err := r.sb.Update("links").
+		Set("leased_until", until).
+		Where(sq.Eq{"id": linkIDs}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("repo: failed to build update query: %w", err)
+	}
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	if _, err := querier.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("repo: failed to lease links: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SquirrelRepository) GetBatch(ctx context.Context, batch uint64) ([]sapi.LinkResponse, error) {
+	query, args, err := r.sb.Select("l.id", "l.url").
+		From("links l").
+		Where(sq.LtOrEq{"l.next_check_at": r.now()}).
+		Where(sq.Or{
+			sq.Eq{"l.leased_until": nil},
+			sq.LtOrEq{"l.leased_until": r.now()},
+		}).
+		OrderBy("l.next_check_at", "l.id").
 		Limit(batch).
+		Suffix("FOR UPDATE OF l SKIP LOCKED").
 		ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("repo: failed to build select query: %w", err)
@@ -141,20 +243,17 @@ func (r *SquirrelRepository) GetBatch(ctx context.Context, batch uint64) ([]sapi
 
 	links := make([]sapi.LinkResponse, 0)
 
+	// Tags and filters belong to subscriptions, a shared link carries none of them.
 	for rows.Next() {
-		var (
-			link    sapi.LinkResponse
-			tags    pgtype.Array[string]
-			filters pgtype.Array[string]
-		)
+		link := sapi.LinkResponse{
+			Tags:    []string{},
+			Filters: []string{},
+		}
 
-		if err := rows.Scan(&link.Id, &link.Url, &tags, &filters); err != nil {
+		if err := rows.Scan(&link.Id, &link.Url); err != nil {
 			return nil, fmt.Errorf("repo: failed to scan row: %w", err)
 		}
 
-		link.Tags = tags.Elements
-		link.Filters = filters.Elements
-
 		links = append(links, link)
 	}
 
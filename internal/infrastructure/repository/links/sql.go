@@ -2,12 +2,14 @@ package links
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	sapi "github.com/es-debug/backend-academy-2024-go-template/internal/api/openapi/v1/servers/scrapper"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/repository/txs"
-	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -24,7 +26,8 @@ func NewSQLRepository(pool *pgxpool.Pool) *SQLRepository {
 }
 
 func (r *SQLRepository) Add(ctx context.Context, url string) (int64, error) {
-	const query = "INSERT INTO links (url, created_at, updated_at) VALUES ($1, $2, $3) RETURNING (id)"
+	const query = `INSERT INTO links (url, created_at, updated_at) VALUES ($1, $2, $3)
+ON CONFLICT (url) DO UPDATE SET url = EXCLUDED.url RETURNING (id)`
 
 	querier := txs.GetQuerier(ctx, r.db)
 
@@ -53,6 +56,18 @@ func (r *SQLRepository) Delete(ctx context.Context, linkID int64) error {
 	return nil
 }
 
+func (r *SQLRepository) DeleteUnused(ctx context.Context, linkID int64) error {
+	const query = "DELETE FROM links WHERE id = $1 AND NOT EXISTS (SELECT 1 FROM subs s WHERE s.link_id = $1)"
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	if _, err := querier.Exec(ctx, query, linkID); err != nil {
+		return fmt.Errorf("repo: failed to delete unused link: %w", err)
+	}
+
+	return nil
+}
+
 func (r *SQLRepository) Touch(ctx context.Context, linkID int64) error {
 	const query = "UPDATE links SET updated_at = $1 WHERE id = $2"
 
@@ -87,15 +102,71 @@ func (r *SQLRepository) UpdateActivity(ctx context.Context, linkID int64, status
 	return nil
 }
 
+func (r *SQLRepository) MarkChecked(ctx context.Context, linkID int64, result models.CheckResult) error {
+	const query = `UPDATE links SET last_checked_at = $1, next_check_at = $2, last_check_status = $3, last_check_error = $4,
+check_interval_ms = $5, score = COALESCE($6, score), leased_until = NULL WHERE id = $7`
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	tag, err := querier.Exec(ctx, query,
+		r.now(), result.NextCheckAt, result.Status, result.Error, result.Interval.Milliseconds(), result.Score, linkID,
+	)
+	if err != nil {
+		return fmt.Errorf("repo: failed to mark link checked: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return sapi.ErrLinkNotExists
+	}
+
+	return nil
+}
+
+func (r *SQLRepository) GetSchedule(ctx context.Context, linkID int64) (models.Schedule, error) {
+	const query = `SELECT l.check_interval_ms, (SELECT COUNT(*) FROM subs s WHERE s.link_id = l.id), l.score
+FROM links l WHERE l.id = $1`
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	var (
+		intervalMs, subscribers int64
+		score                   *int64
+	)
+
+	if err := querier.QueryRow(ctx, query, linkID).Scan(&intervalMs, &subscribers, &score); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Schedule{}, sapi.ErrLinkNotExists
+		}
+		return models.Schedule{}, fmt.Errorf("repo: failed to select link schedule: %w", err)
+	}
+
+	return models.Schedule{
+		Interval:    time.Duration(intervalMs) * time.Millisecond,
+		Subscribers: int(subscribers),
+		Score:       score,
+	}, nil
+}
+
+func (r *SQLRepository) Lease(ctx context.Context, linkIDs []int64, until time.Time) error {
+	const query = "UPDATE links SET leased_until = $1 WHERE id = ANY($2)"
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	if _, err := querier.Exec(ctx, query, until, linkIDs); err != nil {
+		return fmt.Errorf("repo: failed to lease links: %w", err)
+	}
+
+	return nil
+}
+
 func (r *SQLRepository) GetBatch(ctx context.Context, batch uint64) ([]sapi.LinkResponse, error) {
-	const query = `SELECT l.id, l.url, 
-COALESCE((SELECT ARRAY_AGG(t.tag) FROM tags t WHERE t.link_id = l.id), '{}') AS tags,
-COALESCE((SELECT ARRAY_AGG(f.filter_value) FROM filters f WHERE f.link_id = l.id), '{}') AS filters
-FROM links l ORDER BY l.updated_at LIMIT $1`
+	const query = `SELECT l.id, l.url
+FROM links l WHERE l.next_check_at <= $1 AND (l.leased_until IS NULL OR l.leased_until <= $1)
+ORDER BY l.next_check_at, l.id LIMIT $2 FOR UPDATE OF l SKIP LOCKED`
 
 	querier := txs.GetQuerier(ctx, r.db)
 
-	rows, err := querier.Query(ctx, query, batch)
+	rows, err := querier.Query(ctx, query, r.now(), batch)
 	if err != nil {
 		return nil, fmt.Errorf("repo: failed to select links: %w", err)
 	}
@@ -103,20 +174,17 @@ FROM links l ORDER BY l.updated_at LIMIT $1`
 
 	links := make([]sapi.LinkResponse, 0)
 
+	// Tags and filters belong to subscriptions, a shared link carries none of them.
 	for rows.Next() {
-		var (
-			link    sapi.LinkResponse
-			tags    pgtype.Array[string]
-			filters pgtype.Array[string]
-		)
+		link := sapi.LinkResponse{
+			Tags:    []string{},
+			Filters: []string{},
+		}
 
-		if err := rows.Scan(&link.Id, &link.Url, &tags, &filters); err != nil {
+		if err := rows.Scan(&link.Id, &link.Url); err != nil {
 			return nil, fmt.Errorf("repo: failed to scan row: %w", err)
 		}
 
-		link.Tags = tags.Elements
-		link.Filters = filters.Elements
-
 		links = append(links, link)
 	}
 
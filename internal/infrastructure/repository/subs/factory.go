@@ -6,15 +6,21 @@ import (
 
 	"github.com/es-debug/backend-academy-2024-go-template/config"
 	sapi "github.com/es-debug/backend-academy-2024-go-template/internal/api/openapi/v1/servers/scrapper"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type Repository interface {
-	Add(ctx context.Context, chatID, linkID int64) error
+	Add(ctx context.Context, chatID, linkID int64, mode string) error
 	Delete(ctx context.Context, chatID, linkID int64) error
 	GetLinkID(ctx context.Context, url string, chatID int64) (int64, error)
 	GetLinksWithChat(ctx context.Context, chatID int64) ([]sapi.LinkResponse, error)
 	GetLinksWithChatActive(ctx context.Context, chatID int64) ([]sapi.LinkResponse, error)
+	GetLinksWithChatPending(ctx context.Context, chatID int64) ([]sapi.LinkResponse, error)
+	GetCursor(ctx context.Context, chatID, linkID int64) (models.Cursor, error)
+	GetOldestCursor(ctx context.Context, linkID int64) (models.Cursor, error)
+	GetInstantSubscribers(ctx context.Context, linkID int64) ([]models.Subscriber, error)
+	UpdateCursor(ctx context.Context, chatID, linkID int64, cursor models.Cursor) error
 }
 
 type Option func(Repository)
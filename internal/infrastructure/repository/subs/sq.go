@@ -8,6 +8,7 @@ import (
 
 	sq "github.com/Masterminds/squirrel"
 	sapi "github.com/es-debug/backend-academy-2024-go-template/internal/api/openapi/v1/servers/scrapper"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/repository/txs"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -28,10 +29,10 @@ func NewSquirrelRepository(pool *pgxpool.Pool) *SquirrelRepository {
 	}
 }
 
-func (r *SquirrelRepository) Add(ctx context.Context, chatID, linkID int64) error {
+func (r *SquirrelRepository) Add(ctx context.Context, chatID, linkID int64, mode string) error {
 	sql, args, err := r.sb.Insert("subs").
-		Columns("chat_id", "link_id").
-		Values(chatID, linkID).
+		Columns("chat_id", "link_id", "delivery_mode").
+		Values(chatID, linkID, mode).
 		ToSql()
 	if err != nil {
 		return fmt.Errorf("repo: failed to build insert query: %w", err)
@@ -106,8 +107,8 @@ func (r *SquirrelRepository) GetLinksWithChat(ctx context.Context, chatID int64)
 	sql, args, err := r.sb.Select(
 		"l.id",
 		"l.url",
-		"COALESCE((SELECT ARRAY_AGG(t.tag) FROM tags t WHERE t.link_id = l.id), '{}') AS tags",
-		"COALESCE((SELECT ARRAY_AGG(f.filter_value) FROM filters f WHERE f.link_id = l.id), '{}') AS filters",
+		"COALESCE((SELECT ARRAY_AGG(t.tag) FROM tags t WHERE t.chat_id = s.chat_id AND t.link_id = l.id), '{}') AS tags",
+		"COALESCE((SELECT ARRAY_AGG(f.filter_value) FROM filters f WHERE f.chat_id = s.chat_id AND f.link_id = l.id), '{}') AS filters",
 	).
 		From("subs s").
 		Join("links l ON s.link_id = l.id").
@@ -157,8 +158,8 @@ func (r *SquirrelRepository) GetLinksWithChatActive(ctx context.Context, chatID
 	sql, args, err := r.sb.Select(
 		"l.id",
 		"l.url",
-		"COALESCE((SELECT ARRAY_AGG(t.tag) FROM tags t WHERE t.link_id = l.id), '{}') AS tags",
-		"COALESCE((SELECT ARRAY_AGG(f.filter_value) FROM filters f WHERE f.link_id = l.id), '{}') AS filters",
+		"COALESCE((SELECT ARRAY_AGG(t.tag) FROM tags t WHERE t.chat_id = s.chat_id AND t.link_id = l.id), '{}') AS tags",
+		"COALESCE((SELECT ARRAY_AGG(f.filter_value) FROM filters f WHERE f.chat_id = s.chat_id AND f.link_id = l.id), '{}') AS filters",
 	).
 		From("subs s").
 		Join("links l ON s.link_id = l.id").
@@ -204,3 +205,179 @@ func (r *SquirrelRepository) GetLinksWithChatActive(ctx context.Context, chatID
 
 	return links, nil
 }
+
+func (r *SquirrelRepository) GetLinksWithChatPending(ctx context.Context, chatID int64) ([]sapi.LinkResponse, error) {
+	sql, args, err := r.sb.Select(
+		"l.id",
+		"l.url",
+		"COALESCE((SELECT ARRAY_AGG(t.tag) FROM tags t WHERE t.chat_id = s.chat_id AND t.link_id = l.id), '{}') AS tags",
+		"COALESCE((SELECT ARRAY_AGG(f.filter_value) FROM filters f WHERE f.chat_id = s.chat_id AND f.link_id = l.id), '{}') AS filters",
+	).
+		From("subs s").
+		Join("links l ON s.link_id = l.id").
+		Where(sq.Eq{
+			"s.chat_id": chatID,
+		}).
+		Where(`EXISTS (SELECT 1 FROM updates u WHERE u.link_id = s.link_id
+AND (u.created_at, OCTET_LENGTH(u.external_id), u.external_id)
+> (s.cursor_at, OCTET_LENGTH(s.cursor_id), s.cursor_id COLLATE "C"))`).
+		OrderBy("l.updated_at").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("repo: failed to build select query: %w", err)
+	}
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	rows, err := querier.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("repo: failed to select links: %w", err)
+	}
+	defer rows.Close()
+
+	links := make([]sapi.LinkResponse, 0)
+
+	for rows.Next() {
+		var (
+			link    sapi.LinkResponse
+			tags    pgtype.Array[string]
+			filters pgtype.Array[string]
+		)
+
+		if err := rows.Scan(&link.Id, &link.Url, &tags, &filters); err != nil {
+			return nil, fmt.Errorf("repo: failed to scan row: %w", err)
+		}
+
+		link.Tags = tags.Elements
+		link.Filters = filters.Elements
+
+		links = append(links, link)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("repo: failed to scan rows: %w", err)
+	}
+
+	return links, nil
+}
+
+func (r *SquirrelRepository) GetCursor(ctx context.Context, chatID, linkID int64) (models.Cursor, error) {
+	query, args, err := r.sb.Select("cursor_at", "cursor_id").
+		From("subs").
+		Where(sq.Eq{
+			"chat_id": chatID,
+			"link_id": linkID,
+		}).
+		ToSql()
+	if err != nil {
+		return models.Cursor{}, fmt.Errorf("repo: failed to build select query: %w", err)
+	}
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	var cursor models.Cursor
+	if err := querier.QueryRow(ctx, query, args...).Scan(&cursor.At, &cursor.ID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Cursor{}, fmt.Errorf("repo: %w", sapi.ErrSubscriptionsNotExists)
+		}
+		return models.Cursor{}, fmt.Errorf("repo: failed to select cursor: %w", err)
+	}
+
+	return cursor, nil
+}
+
+func (r *SquirrelRepository) GetOldestCursor(ctx context.Context, linkID int64) (models.Cursor, error) {
+	query, args, err := r.sb.Select("cursor_at", "cursor_id").
+		From("subs").
+		Where(sq.Eq{"link_id": linkID}).
+		OrderBy("cursor_at", "OCTET_LENGTH(cursor_id)", `cursor_id COLLATE "C"`).
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return models.Cursor{}, fmt.Errorf("repo: failed to build select query: %w", err)
+	}
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	var cursor models.Cursor
+	if err := querier.QueryRow(ctx, query, args...).Scan(&cursor.At, &cursor.ID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Cursor{}, fmt.Errorf("repo: %w", sapi.ErrSubscriptionsNotExists)
+		}
+		return models.Cursor{}, fmt.Errorf("repo: failed to select cursor: %w", err)
+	}
+
+	return cursor, nil
+}
+
+func (r *SquirrelRepository) UpdateCursor(ctx context.Context, chatID, linkID int64, cursor models.Cursor) error {
+	query, args, err := r.sb.Update("subs").
+		Set("cursor_at", cursor.At).
+		Set("cursor_id", cursor.ID).
+		Where(sq.Eq{
+			"chat_id": chatID,
+			"link_id": linkID,
+		}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("repo: failed to build update query: %w", err)
+	}
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	result, err := querier.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("repo: failed to update cursor: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("repo: %w", sapi.ErrSubscriptionsNotExists)
+	}
+
+	return nil
+}
+
+func (r *SquirrelRepository) GetInstantSubscribers(ctx context.Context, linkID int64) ([]models.Subscriber, error) {
+	query, args, err := r.sb.Select(
+		"s.chat_id",
+		"COALESCE((SELECT ARRAY_AGG(f.filter_value) FROM filters f "+
+			"WHERE f.chat_id = s.chat_id AND f.link_id = s.link_id), '{}') AS filters",
+	).
+		From("subs s").
+		Where(sq.Eq{"s.link_id": linkID, "s.delivery_mode": string(sapi.Instant)}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("repo: failed to build select query: %w", err)
+	}
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	rows, err := querier.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("repo: failed to select instant subscribers: %w", err)
+	}
+	defer rows.Close()
+
+	subscribers := make([]models.Subscriber, 0)
+
+	for rows.Next() {
+		var (
+			subscriber models.Subscriber
+			filters    pgtype.Array[string]
+		)
+
+		if err := rows.Scan(&subscriber.ChatID, &filters); err != nil {
+			return nil, fmt.Errorf("repo: failed to scan row: %w", err)
+		}
+
+		subscriber.Filters = filters.Elements
+
+		subscribers = append(subscribers, subscriber)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repo: failed to scan rows: %w", err)
+	}
+
+	return subscribers, nil
+}
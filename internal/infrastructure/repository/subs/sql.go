@@ -7,6 +7,7 @@ import (
 	"time"
 
 	sapi "github.com/es-debug/backend-academy-2024-go-template/internal/api/openapi/v1/servers/scrapper"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/repository/txs"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -25,12 +26,12 @@ func NewSQLRepository(pool *pgxpool.Pool) *SQLRepository {
 	}
 }
 
-func (r *SQLRepository) Add(ctx context.Context, chatID, linkID int64) error {
-	const query = "INSERT INTO subs (chat_id, link_id) VALUES ($1, $2)"
+func (r *SQLRepository) Add(ctx context.Context, chatID, linkID int64, mode string) error {
+	const query = "INSERT INTO subs (chat_id, link_id, delivery_mode) VALUES ($1, $2, $3)"
 
 	querier := txs.GetQuerier(ctx, r.db)
 
-	result, err := querier.Exec(ctx, query, chatID, linkID)
+	result, err := querier.Exec(ctx, query, chatID, linkID, mode)
 	if err != nil {
 		return fmt.Errorf("repo: failed to insert subscription: %w", err)
 	}
@@ -75,8 +76,8 @@ func (r *SQLRepository) GetLinkID(ctx context.Context, url string, chatID int64)
 
 func (r *SQLRepository) GetLinksWithChat(ctx context.Context, chatID int64) ([]sapi.LinkResponse, error) {
 	const query = `SELECT l.id, l.url, 
-COALESCE((SELECT ARRAY_AGG(t.tag) FROM tags t WHERE t.link_id = l.id), '{}') AS tags,
-COALESCE((SELECT ARRAY_AGG(f.filter_value) FROM filters f WHERE f.link_id = l.id), '{}') AS filters
+COALESCE((SELECT ARRAY_AGG(t.tag) FROM tags t WHERE t.chat_id = s.chat_id AND t.link_id = l.id), '{}') AS tags,
+COALESCE((SELECT ARRAY_AGG(f.filter_value) FROM filters f WHERE f.chat_id = s.chat_id AND f.link_id = l.id), '{}') AS filters
 FROM subs s JOIN links l ON l.id = s.link_id WHERE s.chat_id = $1 ORDER BY l.updated_at`
 
 	querier := txs.GetQuerier(ctx, r.db)
@@ -115,8 +116,8 @@ FROM subs s JOIN links l ON l.id = s.link_id WHERE s.chat_id = $1 ORDER BY l.upd
 
 func (r *SQLRepository) GetLinksWithChatActive(ctx context.Context, chatID int64) ([]sapi.LinkResponse, error) {
 	const query = `SELECT l.id, l.url, 
-COALESCE((SELECT ARRAY_AGG(t.tag) FROM tags t WHERE t.link_id = l.id), '{}') AS tags,
-COALESCE((SELECT ARRAY_AGG(f.filter_value) FROM filters f WHERE f.link_id = l.id), '{}') AS filters
+COALESCE((SELECT ARRAY_AGG(t.tag) FROM tags t WHERE t.chat_id = s.chat_id AND t.link_id = l.id), '{}') AS tags,
+COALESCE((SELECT ARRAY_AGG(f.filter_value) FROM filters f WHERE f.chat_id = s.chat_id AND f.link_id = l.id), '{}') AS filters
 FROM subs s JOIN links l ON l.id = s.link_id WHERE s.chat_id = $1 AND l.is_active = TRUE ORDER BY l.updated_at`
 
 	querier := txs.GetQuerier(ctx, r.db)
@@ -152,3 +153,133 @@ FROM subs s JOIN links l ON l.id = s.link_id WHERE s.chat_id = $1 AND l.is_activ
 
 	return links, nil
 }
+
+func (r *SQLRepository) GetLinksWithChatPending(ctx context.Context, chatID int64) ([]sapi.LinkResponse, error) {
+	const query = `SELECT l.id, l.url,
+COALESCE((SELECT ARRAY_AGG(t.tag) FROM tags t WHERE t.chat_id = s.chat_id AND t.link_id = l.id), '{}') AS tags,
+COALESCE((SELECT ARRAY_AGG(f.filter_value) FROM filters f WHERE f.chat_id = s.chat_id AND f.link_id = l.id), '{}') AS filters
+FROM subs s JOIN links l ON l.id = s.link_id WHERE s.chat_id = $1 AND EXISTS (
+SELECT 1 FROM updates u WHERE u.link_id = s.link_id
+AND (u.created_at, OCTET_LENGTH(u.external_id), u.external_id)
+> (s.cursor_at, OCTET_LENGTH(s.cursor_id), s.cursor_id COLLATE "C")) ORDER BY l.updated_at`
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	rows, err := querier.Query(ctx, query, chatID)
+	if err != nil {
+		return nil, fmt.Errorf("repo: failed to select links: %w", err)
+	}
+	defer rows.Close()
+
+	links := make([]sapi.LinkResponse, 0)
+
+	for rows.Next() {
+		var (
+			link    sapi.LinkResponse
+			tags    pgtype.Array[string]
+			filters pgtype.Array[string]
+		)
+
+		if err := rows.Scan(&link.Id, &link.Url, &tags, &filters); err != nil {
+			return nil, fmt.Errorf("repo: failed to scan row: %w", err)
+		}
+
+		link.Tags = tags.Elements
+		link.Filters = filters.Elements
+
+		links = append(links, link)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("repo: failed to scan rows: %w", err)
+	}
+
+	return links, nil
+}
+
+func (r *SQLRepository) GetCursor(ctx context.Context, chatID, linkID int64) (models.Cursor, error) {
+	const query = "SELECT cursor_at, cursor_id FROM subs WHERE chat_id = $1 AND link_id = $2"
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	var cursor models.Cursor
+	if err := querier.QueryRow(ctx, query, chatID, linkID).Scan(&cursor.At, &cursor.ID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Cursor{}, fmt.Errorf("repo: %w", sapi.ErrSubscriptionsNotExists)
+		}
+		return models.Cursor{}, fmt.Errorf("repo: failed to select cursor: %w", err)
+	}
+
+	return cursor, nil
+}
+
+func (r *SQLRepository) GetOldestCursor(ctx context.Context, linkID int64) (models.Cursor, error) {
+	const query = `SELECT cursor_at, cursor_id FROM subs WHERE link_id = $1
+ORDER BY cursor_at, OCTET_LENGTH(cursor_id), cursor_id COLLATE "C" LIMIT 1`
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	var cursor models.Cursor
+	if err := querier.QueryRow(ctx, query, linkID).Scan(&cursor.At, &cursor.ID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Cursor{}, fmt.Errorf("repo: %w", sapi.ErrSubscriptionsNotExists)
+		}
+		return models.Cursor{}, fmt.Errorf("repo: failed to select cursor: %w", err)
+	}
+
+	return cursor, nil
+}
+
+func (r *SQLRepository) UpdateCursor(ctx context.Context, chatID, linkID int64, cursor models.Cursor) error {
+	const query = "UPDATE subs SET cursor_at = $1, cursor_id = $2 WHERE chat_id = $3 AND link_id = $4"
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	result, err := querier.Exec(ctx, query, cursor.At, cursor.ID, chatID, linkID)
+	if err != nil {
+		return fmt.Errorf("repo: failed to update cursor: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("repo: %w", sapi.ErrSubscriptionsNotExists)
+	}
+
+	return nil
+}
+
+func (r *SQLRepository) GetInstantSubscribers(ctx context.Context, linkID int64) ([]models.Subscriber, error) {
+	const query = `SELECT s.chat_id,
+COALESCE((SELECT ARRAY_AGG(f.filter_value) FROM filters f WHERE f.chat_id = s.chat_id AND f.link_id = s.link_id), '{}')
+FROM subs s WHERE s.link_id = $1 AND s.delivery_mode = $2`
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	rows, err := querier.Query(ctx, query, linkID, string(sapi.Instant))
+	if err != nil {
+		return nil, fmt.Errorf("repo: failed to select instant subscribers: %w", err)
+	}
+	defer rows.Close()
+
+	subscribers := make([]models.Subscriber, 0)
+
+	for rows.Next() {
+		var (
+			subscriber models.Subscriber
+			filters    pgtype.Array[string]
+		)
+
+		if err := rows.Scan(&subscriber.ChatID, &filters); err != nil {
+			return nil, fmt.Errorf("repo: failed to scan row: %w", err)
+		}
+
+		subscriber.Filters = filters.Elements
+
+		subscribers = append(subscribers, subscriber)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repo: failed to scan rows: %w", err)
+	}
+
+	return subscribers, nil
+}
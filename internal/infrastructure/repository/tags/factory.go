@@ -9,7 +9,7 @@ import (
 )
 
 type Repository interface {
-	Add(ctx context.Context, tag string, linkID int64) error
+	Add(ctx context.Context, tag string, chatID, linkID int64) error
 }
 
 type Option func(Repository)
@@ -25,10 +25,10 @@ func NewSquirrelRepository(pool *pgxpool.Pool) *SquirrelRepository {
 	}
 }
 
-func (r *SquirrelRepository) Add(ctx context.Context, tag string, linkID int64) error {
+func (r *SquirrelRepository) Add(ctx context.Context, tag string, chatID, linkID int64) error {
 	sql, args, err := r.sb.Insert("tags").
-		Columns("tag", "link_id").
-		Values(tag, linkID).
+		Columns("tag", "chat_id", "link_id").
+		Values(tag, chatID, linkID).
 		ToSql()
 	if err != nil {
 		return fmt.Errorf("repo: failed to build insert query: %w", err)
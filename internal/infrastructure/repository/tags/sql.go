@@ -22,12 +22,12 @@ func NewSQLRepository(pool *pgxpool.Pool) *SQLRepository {
 	}
 }
 
-func (r *SQLRepository) Add(ctx context.Context, tag string, linkID int64) error {
-	const query = "INSERT INTO tags (tag, link_id) VALUES ($1, $2)"
+func (r *SQLRepository) Add(ctx context.Context, tag string, chatID, linkID int64) error {
+	const query = "INSERT INTO tags (tag, chat_id, link_id) VALUES ($1, $2, $3)"
 
 	querier := txs.GetQuerier(ctx, r.db)
 
-	result, err := querier.Exec(ctx, query, tag, linkID)
+	result, err := querier.Exec(ctx, query, tag, chatID, linkID)
 	if err != nil {
 		return fmt.Errorf("repo: failed to insert tag: %w", err)
 	}
@@ -0,0 +1,26 @@
+package updates
+
+import (
+	"context"
+
+	"github.com/es-debug/backend-academy-2024-go-template/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Repository interface {
+	Add(ctx context.Context, linkID int64, update models.Update) (bool, error)
+	GetLatest(ctx context.Context, linkID int64) (models.Cursor, error)
+	GetSince(ctx context.Context, linkID int64, cursor models.Cursor) ([]models.Update, error)
+}
+
+func New(cfg config.Database, pool *pgxpool.Pool) Repository {
+	switch cfg.Access {
+	case config.Orm:
+		return NewSquirrelRepository(pool)
+	case config.Sql:
+		return NewSQLRepository(pool)
+	}
+
+	return nil
+}
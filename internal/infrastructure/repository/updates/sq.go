@@ -0,0 +1,136 @@
+package updates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/repository/txs"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SquirrelRepository struct {
+	db *pgxpool.Pool
+	sb sq.StatementBuilderType
+}
+
+func NewSquirrelRepository(pool *pgxpool.Pool) *SquirrelRepository {
+	return &SquirrelRepository{
+		db: pool,
+		sb: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+func (r *SquirrelRepository) Add(ctx context.Context, linkID int64, update models.Update) (bool, error) {
+	labels := update.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+
+	query, args, err := r.sb.Insert("updates").
+		Columns(
+			"link_id", "external_id", "source", "kind", "title", "author", "url", "body", "labels",
+			"created_at", "updated_at",
+		).
+		Values(
+			linkID, update.ID, update.Source, update.Kind, update.Title, update.Author, update.URL, update.Body, labels,
+			update.CreatedAt, update.UpdatedAt,
+		).
+		Suffix("ON CONFLICT (link_id, external_id) DO NOTHING").
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("repo: failed to build insert query: %w", err)
+	}
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	result, err := querier.Exec(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("repo: failed to insert update: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+func (r *SquirrelRepository) GetLatest(ctx context.Context, linkID int64) (models.Cursor, error) {
+	query, args, err := r.sb.Select("created_at", "external_id").
+		From("updates").
+		Where(sq.Eq{"link_id": linkID}).
+		OrderBy("created_at DESC", "OCTET_LENGTH(external_id) DESC", "external_id DESC").
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return models.Cursor{}, fmt.Errorf("repo: failed to build select query: %w", err)
+	}
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	var cursor models.Cursor
+	if err := querier.QueryRow(ctx, query, args...).Scan(&cursor.At, &cursor.ID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Cursor{}, nil
+		}
+		return models.Cursor{}, fmt.Errorf("repo: failed to select latest update: %w", err)
+	}
+
+	return cursor, nil
+}
+
+func (r *SquirrelRepository) GetSince(ctx context.Context, linkID int64, cursor models.Cursor) ([]models.Update, error) {
+	query, args, err := r.sb.Select(
+		"external_id", "source", "kind", "title", "url", "created_at", "updated_at", "author", "body", "labels",
+	).
+		From("updates").
+		Where(sq.Eq{"link_id": linkID}).
+		Where(sq.Expr("(created_at, OCTET_LENGTH(external_id), external_id) > (?, OCTET_LENGTH(?::TEXT), ?)",
+			cursor.At, cursor.ID, cursor.ID)).
+		OrderBy("created_at", "OCTET_LENGTH(external_id)", "external_id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("repo: failed to build select query: %w", err)
+	}
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	rows, err := querier.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("repo: failed to select updates: %w", err)
+	}
+	defer rows.Close()
+
+	return scanUpdates(rows)
+}
+
+func scanUpdates(rows pgx.Rows) ([]models.Update, error) {
+	updates := make([]models.Update, 0)
+
+	for rows.Next() {
+		var (
+			update models.Update
+			labels pgtype.Array[string]
+		)
+
+		if err := rows.Scan(
+			&update.ID, &update.Source, &update.Kind, &update.Title, &update.URL,
+			&update.CreatedAt, &update.UpdatedAt, &update.Author, &update.Body, &labels,
+		); err != nil {
+			return nil, fmt.Errorf("repo: failed to scan row: %w", err)
+		}
+
+		update.CreatedAt = update.CreatedAt.UTC()
+		update.UpdatedAt = update.UpdatedAt.UTC()
+		update.Labels = labels.Elements
+
+		updates = append(updates, update)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repo: failed to scan rows: %w", err)
+	}
+
+	return updates, nil
+}
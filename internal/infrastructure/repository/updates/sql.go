@@ -0,0 +1,78 @@
+package updates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/repository/txs"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SQLRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewSQLRepository(pool *pgxpool.Pool) *SQLRepository {
+	return &SQLRepository{
+		db: pool,
+	}
+}
+
+func (r *SQLRepository) Add(ctx context.Context, linkID int64, update models.Update) (bool, error) {
+	const query = `INSERT INTO updates
+(link_id, external_id, source, kind, title, author, url, body, labels, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) ON CONFLICT (link_id, external_id) DO NOTHING`
+
+	labels := update.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	result, err := querier.Exec(ctx, query,
+		linkID, update.ID, update.Source, update.Kind, update.Title, update.Author, update.URL, update.Body, labels,
+		update.CreatedAt, update.UpdatedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("repo: failed to insert update: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+func (r *SQLRepository) GetLatest(ctx context.Context, linkID int64) (models.Cursor, error) {
+	const query = `SELECT created_at, external_id FROM updates WHERE link_id = $1
+ORDER BY created_at DESC, OCTET_LENGTH(external_id) DESC, external_id DESC LIMIT 1`
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	var cursor models.Cursor
+	if err := querier.QueryRow(ctx, query, linkID).Scan(&cursor.At, &cursor.ID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Cursor{}, nil
+		}
+		return models.Cursor{}, fmt.Errorf("repo: failed to select latest update: %w", err)
+	}
+
+	return cursor, nil
+}
+
+func (r *SQLRepository) GetSince(ctx context.Context, linkID int64, cursor models.Cursor) ([]models.Update, error) {
+	const query = `SELECT external_id, source, kind, title, url, created_at, updated_at, author, body, labels FROM updates
+WHERE link_id = $1 AND (created_at, OCTET_LENGTH(external_id), external_id) > ($2, OCTET_LENGTH($3::TEXT), $3)
+ORDER BY created_at, OCTET_LENGTH(external_id), external_id`
+
+	querier := txs.GetQuerier(ctx, r.db)
+
+	rows, err := querier.Query(ctx, query, linkID, cursor.At, cursor.ID)
+	if err != nil {
+		return nil, fmt.Errorf("repo: failed to select updates: %w", err)
+	}
+	defer rows.Close()
+
+	return scanUpdates(rows)
+}
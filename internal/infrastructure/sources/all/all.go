@@ -0,0 +1,9 @@
+// Package all registers every source the scrapper and the bot support.
+package all
+
+import (
+	_ "github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/sources/feed"
+	_ "github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/sources/github"
+	_ "github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/sources/gitlab"
+	_ "github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/sources/stackoverflow"
+)
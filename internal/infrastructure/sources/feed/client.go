@@ -0,0 +1,111 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+	"github.com/es-debug/backend-academy-2024-go-template/pkg/lru"
+	"github.com/es-debug/backend-academy-2024-go-template/pkg/safedial"
+	"resty.dev/v3"
+)
+
+// validators are what a feed server answered last time, sent back so an
+// unchanged feed costs a 304 instead of a full download.
+type validators struct {
+	etag         string
+	lastModified string
+}
+
+// defaultCacheSize bounds the validators kept when the configuration leaves it unset.
+const defaultCacheSize = 10000
+
+type FeedClient struct {
+	client     *resty.Client
+	validators *lru.Cache[string, validators]
+}
+
+func NewFeedClient(cfg *config.Config) *FeedClient {
+	cb := resty.NewCircuitBreaker().
+		SetTimeout(cfg.CircuitBreakerPolicy.Timeout).
+		SetFailureThreshold(cfg.CircuitBreakerPolicy.FailureThreshold).
+		SetSuccessThreshold(cfg.CircuitBreakerPolicy.MaxRequests)
+
+	client := resty.New().
+		SetHeader("Accept", "application/atom+xml, application/rss+xml, application/xml;q=0.9, text/xml;q=0.8").
+		SetTimeout(cfg.TimeoutPolicy.ClientOverall).
+		SetRetryCount(int(cfg.RetryPolicy.Attempts)).
+		SetRetryWaitTime(cfg.RetryPolicy.Delay).
+		AddRetryConditions(func(res *resty.Response, err error) bool {
+			return !slices.Contains(cfg.RetryPolicy.StatusCodes, res.StatusCode())
+		}).
+		SetCircuitBreaker(cb)
+
+	// Feeds live on arbitrary hosts, so unless told otherwise the client
+	// never connects to loopback, private or link-local addresses.
+	if !cfg.Feeds.AllowPrivateHosts {
+		client.SetTransport(safedial.Transport())
+	}
+
+	size := cfg.Feeds.CacheSize
+	if size <= 0 {
+		size = defaultCacheSize
+	}
+
+	return &FeedClient{
+		client:     client,
+		validators: lru.New[string, validators](size),
+	}
+}
+
+func (f *FeedClient) RetrieveUpdates(ctx context.Context, link string, since time.Time) ([]models.Update, error) {
+	req := f.client.R().SetContext(ctx)
+
+	cached, ok := f.validators.Get(link)
+
+	if ok {
+		if cached.etag != "" {
+			req.SetHeader("If-None-Match", cached.etag)
+		}
+
+		if cached.lastModified != "" {
+			req.SetHeader("If-Modified-Since", cached.lastModified)
+		}
+	}
+
+	res, err := req.Get(link)
+	if err != nil {
+		return nil, fmt.Errorf("feed client: failed to fetch feed: %w", err)
+	}
+
+	switch {
+	case res.StatusCode() == http.StatusNotModified:
+		return []models.Update{}, nil
+	case !res.IsSuccess():
+		return nil, fmt.Errorf("feed client: feed responded with %s", res.Status())
+	}
+
+	updates, err := Parse(res.Bytes(), time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("feed client: %w", err)
+	}
+
+	f.validators.Put(link, validators{
+		etag:         res.Header().Get("ETag"),
+		lastModified: res.Header().Get("Last-Modified"),
+	})
+
+	return updates, nil
+}
+
+func (f *FeedClient) Close() error {
+	if err := f.client.Close(); err != nil {
+		return fmt.Errorf("feed client: failed to cleanup resources: %w", err)
+	}
+
+	return nil
+}
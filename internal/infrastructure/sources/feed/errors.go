@@ -0,0 +1,12 @@
+package feed
+
+import "fmt"
+
+type feedErr struct{ msg string }
+
+func (e feedErr) Error() string { return fmt.Sprintf("error: %s", e.msg) }
+
+var (
+	ErrMalformedFeed   = feedErr{msg: "feed is not well-formed xml"}
+	ErrUnsupportedFeed = feedErr{msg: "document is neither an rss nor an atom feed"}
+)
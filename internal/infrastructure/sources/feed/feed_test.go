@@ -0,0 +1,191 @@
+package feed_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/sources/feed"
+	"github.com/es-debug/backend-academy-2024-go-template/pkg/safedial"
+	"github.com/stretchr/testify/require"
+)
+
+const rssFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:dc="http://purl.org/dc/elements/1.1/">
+  <channel>
+    <title>Status</title>
+    <item>
+      <guid>incident-2</guid>
+      <title>Degraded API</title>
+      <link>https://status.example.com/2</link>
+      <description>Investigating</description>
+      <pubDate>Mon, 2 Jun 2025 10:00:00 GMT</pubDate>
+      <dc:creator>ops</dc:creator>
+      <category>api</category>
+    </item>
+    <item>
+      <guid>incident-2</guid>
+      <title>Degraded API (repeated)</title>
+    </item>
+    <item>
+      <title>Undated</title>
+      <link>https://status.example.com/1</link>
+    </item>
+  </channel>
+</rss>`
+
+const atomFeed = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>The Go Blog</title>
+  <author><name>Go Team</name></author>
+  <entry>
+    <id>tag:blog.golang.org,2013:blog.golang.org/go1.24</id>
+    <title>Go 1.24 is released!</title>
+    <link rel="alternate" href="https://go.dev/blog/go1.24"></link>
+    <published>2025-02-11T00:00:00+00:00</published>
+    <updated>2025-02-12T00:00:00+00:00</updated>
+    <summary>Go 1.24 brings generic type aliases.</summary>
+    <category term="release"></category>
+  </entry>
+</feed>`
+
+func TestParse(t *testing.T) {
+	now := time.Date(2025, 6, 5, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		body     string
+		expected []models.Update
+		wantErr  bool
+	}{
+		"rss items deduplicated by guid": {
+			body: rssFeed,
+			expected: []models.Update{
+				models.NewUpdate("incident-2", models.KindPost, "Degraded API", "https://status.example.com/2",
+					time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC), "ops", "Investigating", "api"),
+				models.NewUpdate("https://status.example.com/1", models.KindPost, "Undated", "https://status.example.com/1",
+					now, "", ""),
+			},
+		},
+		"atom entries inherit the feed author": {
+			body: atomFeed,
+			expected: []models.Update{
+				models.NewUpdate("tag:blog.golang.org,2013:blog.golang.org/go1.24", models.KindPost,
+					"Go 1.24 is released!", "https://go.dev/blog/go1.24", time.Date(2025, 2, 11, 0, 0, 0, 0, time.UTC), "Go Team",
+					"Go 1.24 brings generic type aliases.", "release"),
+			},
+		},
+		"not a feed": {
+			body:    `<html><body>hello</body></html>`,
+			wantErr: true,
+		},
+		"not xml": {
+			body:    `{"items": []}`,
+			wantErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			updates, err := feed.Parse([]byte(test.body), now)
+
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, len(test.expected), len(updates))
+
+			for i, expected := range test.expected {
+				require.Equal(t, expected.ID, updates[i].ID)
+				require.Equal(t, expected.Title, updates[i].Title)
+				require.Equal(t, expected.URL, updates[i].URL)
+				require.True(t, expected.CreatedAt.Equal(updates[i].CreatedAt))
+				require.Equal(t, expected.Author, updates[i].Author)
+				require.Equal(t, expected.Body, updates[i].Body)
+				require.ElementsMatch(t, expected.Labels, updates[i].Labels)
+			}
+		})
+	}
+}
+
+func TestRetrieveUpdatesConditional(t *testing.T) {
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/atom+xml")
+		_, _ = w.Write([]byte(atomFeed))
+	}))
+	defer server.Close()
+
+	client := feed.NewFeedClient(&config.Config{Feeds: config.Feeds{AllowPrivateHosts: true}})
+	defer client.Close()
+
+	ctx := context.Background()
+	link := server.URL + "/blog/feed.atom"
+
+	updates, err := client.RetrieveUpdates(ctx, link, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, updates, 1)
+
+	updates, err = client.RetrieveUpdates(ctx, link, time.Time{})
+	require.NoError(t, err)
+	require.Empty(t, updates)
+	require.Equal(t, 2, requests)
+}
+
+func TestRetrieveUpdatesEvictsValidators(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(atomFeed))
+	}))
+	defer server.Close()
+
+	client := feed.NewFeedClient(&config.Config{Feeds: config.Feeds{AllowPrivateHosts: true, CacheSize: 1}})
+	defer client.Close()
+
+	ctx := context.Background()
+
+	for _, path := range []string{"/a/feed.atom", "/b/feed.atom"} {
+		_, err := client.RetrieveUpdates(ctx, server.URL+path, time.Time{})
+		require.NoError(t, err)
+	}
+
+	updates, err := client.RetrieveUpdates(ctx, server.URL+"/a/feed.atom", time.Time{})
+	require.NoError(t, err)
+	require.Len(t, updates, 1)
+}
+
+func TestRetrieveUpdatesRefusesPrivateHosts(t *testing.T) {
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(atomFeed))
+	}))
+	defer server.Close()
+
+	client := feed.NewFeedClient(&config.Config{})
+	defer client.Close()
+
+	_, err := client.RetrieveUpdates(context.Background(), server.URL+"/blog/feed.atom", time.Time{})
+	require.ErrorIs(t, err, safedial.ErrForbiddenAddress)
+	require.Zero(t, requests)
+}
@@ -0,0 +1,194 @@
+package feed
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+)
+
+type rssDocument struct {
+	Channel struct {
+		Items []rssItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+type rssItem struct {
+	GUID        string   `xml:"guid"`
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	Description string   `xml:"description"`
+	PubDate     string   `xml:"pubDate"`
+	Author      string   `xml:"author"`
+	Creator     string   `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	Categories  []string `xml:"category"`
+}
+
+type atomDocument struct {
+	Author  atomPerson  `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	ID        string     `xml:"id"`
+	Title     string     `xml:"title"`
+	Updated   string     `xml:"updated"`
+	Published string     `xml:"published"`
+	Summary   string     `xml:"summary"`
+	Content   string     `xml:"content"`
+	Author    atomPerson `xml:"author"`
+	Links     []struct {
+		Href string `xml:"href,attr"`
+		Rel  string `xml:"rel,attr"`
+	} `xml:"link"`
+	Categories []struct {
+		Term string `xml:"term,attr"`
+	} `xml:"category"`
+}
+
+// dateLayouts covers RFC 822 dates as feeds actually write them, with and without
+// weekdays, seconds and numeric zones, and the RFC 3339 dates of Atom.
+var dateLayouts = []string{
+	time.RFC3339,
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	"2 Jan 2006 15:04:05 MST",
+	"Mon, 2 Jan 2006 15:04 -0700",
+	"Mon, 2 Jan 2006 15:04 MST",
+	time.RFC822Z,
+	time.RFC822,
+}
+
+// Parse reads an RSS 2.0 or Atom document into updates, one per item or entry.
+// Items without a date are stamped with now, so the first sighting counts as their publication.
+func Parse(body []byte, now time.Time) ([]models.Update, error) {
+	var root struct {
+		XMLName xml.Name
+	}
+
+	if err := xml.NewDecoder(bytes.NewReader(body)).Decode(&root); err != nil {
+		return nil, ErrMalformedFeed
+	}
+
+	switch root.XMLName.Local {
+	case "rss":
+		var doc rssDocument
+		if err := xml.Unmarshal(body, &doc); err != nil {
+			return nil, ErrMalformedFeed
+		}
+
+		return fromRSS(doc, now), nil
+	case "feed":
+		var doc atomDocument
+		if err := xml.Unmarshal(body, &doc); err != nil {
+			return nil, ErrMalformedFeed
+		}
+
+		return fromAtom(doc, now), nil
+	}
+
+	return nil, ErrUnsupportedFeed
+}
+
+func fromRSS(doc rssDocument, now time.Time) []models.Update {
+	updates := make([]models.Update, 0, len(doc.Channel.Items))
+	seen := make(map[string]struct{}, len(doc.Channel.Items))
+
+	for _, item := range doc.Channel.Items {
+		id := firstOf(item.GUID, item.Link, item.Title)
+		if _, ok := seen[id]; ok || id == "" {
+			continue
+		}
+		seen[id] = struct{}{}
+
+		updates = append(updates, models.NewUpdate(
+			id,
+			models.KindPost,
+			strings.TrimSpace(item.Title),
+			strings.TrimSpace(item.Link),
+			date(now, item.PubDate),
+			strings.TrimSpace(firstOf(item.Creator, item.Author)),
+			strings.TrimSpace(item.Description),
+			item.Categories...,
+		))
+	}
+
+	return updates
+}
+
+func fromAtom(doc atomDocument, now time.Time) []models.Update {
+	updates := make([]models.Update, 0, len(doc.Entries))
+	seen := make(map[string]struct{}, len(doc.Entries))
+
+	for _, entry := range doc.Entries {
+		link := ""
+
+		for _, l := range entry.Links {
+			if l.Rel == "" || l.Rel == "alternate" {
+				link = l.Href
+				break
+			}
+		}
+
+		id := firstOf(entry.ID, link, entry.Title)
+		if _, ok := seen[id]; ok || id == "" {
+			continue
+		}
+		seen[id] = struct{}{}
+
+		labels := make([]string, 0, len(entry.Categories))
+		for _, category := range entry.Categories {
+			labels = append(labels, category.Term)
+		}
+
+		update := models.NewUpdate(
+			id,
+			models.KindPost,
+			strings.TrimSpace(entry.Title),
+			strings.TrimSpace(link),
+			date(now, entry.Published, entry.Updated),
+			strings.TrimSpace(firstOf(entry.Author.Name, doc.Author.Name)),
+			strings.TrimSpace(firstOf(entry.Summary, entry.Content)),
+			labels...,
+		)
+		update.UpdatedAt = date(now, entry.Updated, entry.Published)
+
+		updates = append(updates, update)
+	}
+
+	return updates
+}
+
+// date returns the first of the values that parses, or now when none does.
+func date(now time.Time, values ...string) time.Time {
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+
+		for _, layout := range dateLayouts {
+			if t, err := time.Parse(layout, value); err == nil {
+				return t.UTC()
+			}
+		}
+	}
+
+	return now.UTC().Truncate(time.Second)
+}
+
+func firstOf(values ...string) string {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,63 @@
+package feed
+
+import (
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/es-debug/backend-academy-2024-go-template/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/sources"
+)
+
+var (
+	extensions = []string{".atom", ".rss", ".xml"}
+	names      = []string{"feed", "rss", "atom"}
+)
+
+func init() {
+	sources.Register(Source{})
+}
+
+// Source tracks RSS 2.0 and Atom feeds on any host. A link is taken for a feed
+// when its path looks like one, e.g. /blog/feed.atom, /index.xml or /releases/rss.
+type Source struct{}
+
+func (Source) Name() string { return config.Feed }
+
+func (Source) Fallback() {}
+
+func (Source) Match(u *url.URL) bool {
+	if u.Scheme != config.Scheme && u.Scheme != config.SchemeSecure {
+		return false
+	}
+
+	base := strings.ToLower(path.Base(u.Path))
+
+	return slices.Contains(names, base) || slices.Contains(extensions, path.Ext(base))
+}
+
+func (Source) Validate(u *url.URL) error {
+	if u.Host == "" {
+		return sources.ErrInvalidPath
+	}
+
+	return nil
+}
+
+// Canonical lowercases the host and drops the fragment. The scheme, path and
+// query are kept, since feeds are served by arbitrary software that may tell them apart.
+func (Source) Canonical(u *url.URL) string {
+	canonical := url.URL{
+		Scheme:   strings.ToLower(u.Scheme),
+		Host:     strings.ToLower(u.Host),
+		Path:     u.Path,
+		RawQuery: u.RawQuery,
+	}
+
+	return canonical.String()
+}
+
+func (Source) NewClient(cfg *config.Config) sources.Client {
+	return NewFeedClient(cfg)
+}
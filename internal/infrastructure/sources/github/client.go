@@ -0,0 +1,178 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/sources"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+	"github.com/es-debug/backend-academy-2024-go-template/pkg/lru"
+	"resty.dev/v3"
+)
+
+// commitsCacheSize bounds the tag commits kept between polls.
+const commitsCacheSize = 1024
+
+type GitHubClient struct {
+	client  *resty.Client
+	commits *lru.Cache[string, GitHubCommit]
+}
+
+func NewGithubClient(cfg *config.Config) *GitHubClient {
+	cb := resty.NewCircuitBreaker().
+		SetTimeout(cfg.CircuitBreakerPolicy.Timeout).
+		SetFailureThreshold(cfg.CircuitBreakerPolicy.FailureThreshold).
+		SetSuccessThreshold(cfg.CircuitBreakerPolicy.MaxRequests)
+
+	client := resty.New().
+		SetBaseURL(strings.TrimSuffix(cfg.GitHub.BaseURL, "/") + "/repos/").
+		SetAuthScheme("Bearer").
+		SetAuthToken(cfg.Secrets.GitHubToken).
+		SetTimeout(cfg.TimeoutPolicy.ClientOverall).
+		SetRetryCount(int(cfg.RetryPolicy.Attempts)).
+		SetRetryWaitTime(cfg.RetryPolicy.Delay).
+		AddRetryConditions(func(res *resty.Response, err error) bool {
+			return !slices.Contains(cfg.RetryPolicy.StatusCodes, res.StatusCode())
+		}).
+		SetCircuitBreaker(cb)
+
+	return &GitHubClient{
+		client:  client,
+		commits: lru.New[string, GitHubCommit](commitsCacheSize),
+	}
+}
+
+type GitHubUpdate struct {
+	ID      int64  `json:"id"`
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	HTMLURL string `json:"html_url"`
+	User    struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	PullRequest *struct{} `json:"pull_request"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (g *GitHubUpdate) labels() []string {
+	labels := make([]string, 0, len(g.Labels))
+	for _, label := range g.Labels {
+		labels = append(labels, label.Name)
+	}
+
+	return labels
+}
+
+func (g *GitHubUpdate) update(kind models.Kind) models.Update {
+	update := models.NewUpdate(
+		strconv.FormatInt(g.ID, 10),
+		kind,
+		g.Title,
+		g.HTMLURL,
+		g.CreatedAt,
+		g.User.Login,
+		g.Body,
+		g.labels()...,
+	)
+	update.UpdatedAt = g.UpdatedAt
+
+	return update
+}
+
+func (g *GitHubClient) RetrieveUpdates(ctx context.Context, link string, since time.Time) ([]models.Update, error) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return nil, fmt.Errorf("github client: failed to parse link")
+	}
+
+	t, err := locate(u)
+	if err != nil {
+		return nil, sources.ErrInvalidPath
+	}
+
+	repo := map[string]string{
+		"owner": t.owner,
+		"repo":  t.repo,
+	}
+
+	// A branch link follows its commits only, not the activity of the whole repository.
+	if t.branch != "" {
+		return g.retrieveCommits(ctx, repo, t, since)
+	}
+
+	// Likewise an issue or pull request link follows that single thread.
+	if t.number != "" {
+		return g.retrieveThread(ctx, repo, t, since)
+	}
+
+	if t.workflow != "" {
+		return g.retrieveWorkflowRuns(ctx, repo, t)
+	}
+
+	var pulls []GitHubUpdate
+	if _, err := g.client.R().
+		SetContext(ctx).
+		SetPathParams(repo).
+		SetQueryParams(map[string]string{
+			"sort":      "created",
+			"direction": "desc",
+		}).
+		SetResult(&pulls).
+		Get("{owner}/{repo}/pulls"); err != nil {
+		return nil, fmt.Errorf("github client: failed to fetch pulls updates")
+	}
+
+	var issues []GitHubUpdate
+	if _, err := g.client.R().
+		SetContext(ctx).
+		SetPathParams(repo).
+		SetQueryParams(map[string]string{
+			"sort":      "created",
+			"direction": "desc",
+			"since":     since.UTC().Format(time.RFC3339),
+		}).
+		SetResult(&issues).
+		Get("{owner}/{repo}/issues"); err != nil {
+		return nil, fmt.Errorf("github client: failed to fetch issues updates")
+	}
+
+	updates := make([]models.Update, 0, len(pulls)+len(issues))
+
+	for _, pull := range pulls {
+		updates = append(updates, pull.update(models.KindPR))
+	}
+
+	for _, issue := range issues {
+		// The issues endpoint lists pull requests too, they are already collected above.
+		if issue.PullRequest != nil {
+			continue
+		}
+
+		updates = append(updates, issue.update(models.KindIssue))
+	}
+
+	releases, err := g.retrieveReleases(ctx, repo, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return append(updates, releases...), nil
+}
+
+func (g *GitHubClient) Close() error {
+	if err := g.client.Close(); err != nil {
+		return fmt.Errorf("github client: failed to cleanup resources: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+)
+
+type GitHubBranchCommit struct {
+	SHA     string `json:"sha"`
+	HTMLURL string `json:"html_url"`
+	Author  *struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	Commit struct {
+		Message string `json:"message"`
+		Author  struct {
+			Name string `json:"name"`
+		} `json:"author"`
+		Committer struct {
+			Date time.Time `json:"date"`
+		} `json:"committer"`
+	} `json:"commit"`
+}
+
+// retrieveCommits lists the commits that reached the branch since the given time
+// and touch the target path, if the link names one.
+func (g *GitHubClient) retrieveCommits(
+	ctx context.Context,
+	repo map[string]string,
+	t target,
+	since time.Time,
+) ([]models.Update, error) {
+	params := map[string]string{
+		"sha":      t.branch,
+		"since":    since.UTC().Format(time.RFC3339),
+		"per_page": pageSize,
+	}
+
+	if t.path != "" {
+		params["path"] = t.path
+	}
+
+	var commits []GitHubBranchCommit
+	if _, err := g.client.R().
+		SetContext(ctx).
+		SetPathParams(repo).
+		SetQueryParams(params).
+		SetResult(&commits).
+		Get("{owner}/{repo}/commits"); err != nil {
+		return nil, fmt.Errorf("github client: failed to fetch commits updates")
+	}
+
+	updates := make([]models.Update, 0, len(commits))
+
+	for _, commit := range commits {
+		author := commit.Commit.Author.Name
+		if commit.Author != nil {
+			author = commit.Author.Login
+		}
+
+		subject, body, _ := strings.Cut(commit.Commit.Message, "\n")
+
+		// GitHub filters by committer date, so that is the date the cursor has to follow.
+		updates = append(updates, models.NewUpdate(
+			"commit:"+commit.SHA,
+			models.KindCommit,
+			strings.TrimSpace(subject),
+			commit.HTMLURL,
+			commit.Commit.Committer.Date,
+			author,
+			strings.TrimSpace(body),
+		))
+	}
+
+	return updates, nil
+}
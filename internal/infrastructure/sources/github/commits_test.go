@@ -0,0 +1,99 @@
+package github_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/sources/github"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetrieveCommits(t *testing.T) {
+	since := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		link     string
+		expected url.Values
+	}{
+		"branch": {
+			link: "https://github.com/acme/lib/tree/main",
+			expected: url.Values{
+				"sha": {"main"}, "since": {"2025-06-01T00:00:00Z"}, "per_page": {"30"},
+			},
+		},
+		"directory on a branch": {
+			link: "https://github.com/acme/lib/tree/main/deploy/prod",
+			expected: url.Values{
+				"sha": {"main"}, "path": {"deploy/prod"}, "since": {"2025-06-01T00:00:00Z"}, "per_page": {"30"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var query url.Values
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/repos/acme/lib/commits" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+
+				query = r.URL.Query()
+
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode([]map[string]any{
+					{
+						"sha": "abc", "html_url": "https://commit/abc",
+						"author": map[string]string{"login": "alice"},
+						"commit": map[string]any{
+							"message":   "Bump chart\n\nRaises the replica count.\n",
+							"author":    map[string]string{"name": "Alice"},
+							"committer": map[string]string{"date": "2025-06-02T10:00:00Z"},
+						},
+					},
+					{
+						"sha": "def", "html_url": "https://commit/def",
+						"author": nil,
+						"commit": map[string]any{
+							"message":   "Fix typo",
+							"author":    map[string]string{"name": "Bob"},
+							"committer": map[string]string{"date": "2025-06-02T11:00:00Z"},
+						},
+					},
+				})
+			}))
+			defer server.Close()
+
+			cfg := &config.Config{}
+			cfg.GitHub.BaseURL = server.URL
+
+			client := github.NewGithubClient(cfg)
+			defer client.Close()
+
+			updates, err := client.RetrieveUpdates(context.Background(), test.link, since)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, query)
+
+			require.Len(t, updates, 2)
+
+			require.Equal(t, "commit:abc", updates[0].ID)
+			require.Equal(t, models.KindCommit, updates[0].Kind)
+			require.Equal(t, "Bump chart", updates[0].Title)
+			require.Equal(t, "Raises the replica count.", updates[0].Body)
+			require.Equal(t, "alice", updates[0].Author)
+			require.Equal(t, time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC), updates[0].CreatedAt)
+
+			require.Equal(t, "Fix typo", updates[1].Title)
+			require.Empty(t, updates[1].Body)
+			require.Equal(t, "Bob", updates[1].Author)
+		})
+	}
+}
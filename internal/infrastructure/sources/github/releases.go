@@ -0,0 +1,238 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+	"github.com/es-debug/backend-academy-2024-go-template/pkg/semver"
+)
+
+// tagsPerPoll bounds the tags without a release that are dated per poll,
+// each of them costs a request for its commit the first time it is seen.
+const tagsPerPoll = 5
+
+const pageSize = "30"
+
+type GitHubRelease struct {
+	ID          int64     `json:"id"`
+	TagName     string    `json:"tag_name"`
+	Name        string    `json:"name"`
+	Body        string    `json:"body"`
+	HTMLURL     string    `json:"html_url"`
+	Draft       bool      `json:"draft"`
+	Prerelease  bool      `json:"prerelease"`
+	PublishedAt time.Time `json:"published_at"`
+	Author      struct {
+		Login string `json:"login"`
+	} `json:"author"`
+}
+
+type GitHubTag struct {
+	Name   string `json:"name"`
+	Commit struct {
+		SHA string `json:"sha"`
+	} `json:"commit"`
+}
+
+type GitHubCommit struct {
+	Author *struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	Commit struct {
+		Author struct {
+			Name string `json:"name"`
+		} `json:"author"`
+	} `json:"commit"`
+}
+
+// retrieveReleases turns published releases, and tags that were pushed without one,
+// into updates labelled with the semver level they raise.
+//
+// The API does not say when a tag was pushed, and a tag on an old commit would fall
+// behind the cursor if it were dated by that commit. Tags are dated by the poll that
+// first sees them instead; the updates table keeps the first copy of each tag:<name>.
+// A freshly followed repository therefore reports its newest tags once.
+func (g *GitHubClient) retrieveReleases(
+	ctx context.Context,
+	repo map[string]string,
+	now time.Time,
+) ([]models.Update, error) {
+	var releases []GitHubRelease
+	if _, err := g.client.R().
+		SetContext(ctx).
+		SetPathParams(repo).
+		SetQueryParam("per_page", pageSize).
+		SetResult(&releases).
+		Get("{owner}/{repo}/releases"); err != nil {
+		return nil, fmt.Errorf("github client: failed to fetch releases updates")
+	}
+
+	var tags []GitHubTag
+	if _, err := g.client.R().
+		SetContext(ctx).
+		SetPathParams(repo).
+		SetQueryParam("per_page", pageSize).
+		SetResult(&tags).
+		Get("{owner}/{repo}/tags"); err != nil {
+		return nil, fmt.Errorf("github client: failed to fetch tags updates")
+	}
+
+	releases = slices.DeleteFunc(releases, func(release GitHubRelease) bool { return release.Draft })
+
+	released := make(map[string]struct{}, len(releases))
+	names := make([]string, 0, len(releases)+len(tags))
+
+	for _, release := range releases {
+		released[release.TagName] = struct{}{}
+		names = append(names, release.TagName)
+	}
+
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+
+	history := stableVersions(names)
+
+	updates := make([]models.Update, 0, len(releases)+tagsPerPoll)
+
+	for _, release := range releases {
+		title := release.Name
+		if title == "" {
+			title = release.TagName
+		}
+
+		updates = append(updates, models.NewUpdate(
+			"release:"+strconv.FormatInt(release.ID, 10),
+			models.KindRelease,
+			title,
+			release.HTMLURL,
+			release.PublishedAt,
+			release.Author.Login,
+			release.Body,
+			versionLabels(release.TagName, release.Prerelease, history)...,
+		))
+	}
+
+	tags = slices.DeleteFunc(tags, func(tag GitHubTag) bool {
+		_, ok := released[tag.Name]
+		return ok
+	})
+
+	// The newest versions go first, tags that are not versions keep the API order after them.
+	slices.SortStableFunc(tags, func(a, b GitHubTag) int {
+		av, aErr := semver.Parse(a.Name)
+		bv, bErr := semver.Parse(b.Name)
+
+		switch {
+		case aErr == nil && bErr == nil:
+			return bv.Compare(av)
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		}
+
+		return 0
+	})
+
+	for _, tag := range tags[:min(len(tags), tagsPerPoll)] {
+		commit, err := g.tagCommit(ctx, repo, tag.Commit.SHA)
+		if err != nil {
+			return nil, err
+		}
+
+		author := commit.Commit.Author.Name
+		if commit.Author != nil {
+			author = commit.Author.Login
+		}
+
+		updates = append(updates, models.NewUpdate(
+			"tag:"+tag.Name,
+			models.KindTag,
+			"tag "+tag.Name,
+			"https://github.com/"+repo["owner"]+"/"+repo["repo"]+"/releases/tag/"+tag.Name,
+			now,
+			author,
+			"",
+			versionLabels(tag.Name, false, history)...,
+		))
+	}
+
+	return updates, nil
+}
+
+// tagCommit fetches the commit a tag points to for its author. Commits never change,
+// so the recently seen ones are kept between polls.
+func (g *GitHubClient) tagCommit(ctx context.Context, repo map[string]string, sha string) (GitHubCommit, error) {
+	if commit, ok := g.commits.Get(sha); ok {
+		return commit, nil
+	}
+
+	var commit GitHubCommit
+
+	res, err := g.client.R().
+		SetContext(ctx).
+		SetPathParams(repo).
+		SetPathParam("sha", sha).
+		SetResult(&commit).
+		Get("{owner}/{repo}/commits/{sha}")
+	if err != nil || !res.IsSuccess() {
+		return GitHubCommit{}, fmt.Errorf("github client: failed to fetch tag commit")
+	}
+
+	g.commits.Put(sha, commit)
+
+	return commit, nil
+}
+
+func stableVersions(names []string) []semver.Version {
+	versions := make([]semver.Version, 0, len(names))
+
+	for _, name := range names {
+		if v, err := semver.Parse(name); err == nil && !v.IsPrerelease() {
+			versions = append(versions, v)
+		}
+	}
+
+	return versions
+}
+
+// versionLabels compares a version with the latest stable one below it.
+// The oldest known version counts as a major release.
+func versionLabels(name string, prerelease bool, history []semver.Version) []string {
+	v, err := semver.Parse(name)
+	if err != nil {
+		return nil
+	}
+
+	var prev *semver.Version
+
+	for i := range history {
+		if history[i].CompareCore(v) < 0 && (prev == nil || history[i].Compare(*prev) > 0) {
+			prev = &history[i]
+		}
+	}
+
+	level := semver.Major
+	if prev != nil {
+		level = semver.Bump(*prev, v)
+	}
+
+	labels := []string{levelLabels[level]}
+
+	if prerelease || v.IsPrerelease() {
+		labels = append(labels, models.LabelPrerelease)
+	}
+
+	return labels
+}
+
+var levelLabels = map[semver.Level]string{
+	semver.Major: models.LabelMajor,
+	semver.Minor: models.LabelMinor,
+	semver.Patch: models.LabelPatch,
+}
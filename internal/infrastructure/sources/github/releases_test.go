@@ -0,0 +1,145 @@
+package github_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/sources/github"
+	"github.com/stretchr/testify/require"
+)
+
+// serve answers the repos API paths with canned responses and counts
+// the requests made for the commits of tags.
+func serve(t *testing.T, responses map[string]any) (*github.GitHubClient, *atomic.Int32) {
+	t.Helper()
+
+	var commits atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		if strings.HasPrefix(r.URL.Path, "/repos/acme/lib/commits/") {
+			commits.Add(1)
+		}
+
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			response = []any{}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{}
+	cfg.GitHub.BaseURL = server.URL
+	cfg.Secrets.GitHubToken = "secret"
+
+	client := github.NewGithubClient(cfg)
+	t.Cleanup(func() { _ = client.Close() })
+
+	return client, &commits
+}
+
+func release(id int64, tag string, draft, prerelease bool) map[string]any {
+	return map[string]any{
+		"id": id, "tag_name": tag, "body": "notes for " + tag, "html_url": "https://release/" + tag,
+		"draft": draft, "prerelease": prerelease, "published_at": "2025-06-02T10:00:00Z",
+		"author": map[string]string{"login": "alice"},
+	}
+}
+
+func tag(name string) map[string]any {
+	return map[string]any{"name": name, "commit": map[string]string{"sha": "sha-" + name}}
+}
+
+func TestRetrieveReleases(t *testing.T) {
+	responses := map[string]any{
+		"/repos/acme/lib/releases": []map[string]any{
+			release(4, "v3.0.0", true, false),
+			release(3, "v2.0.0-rc.1", false, true),
+			release(2, "v1.1.0", false, false),
+			release(1, "v1.0.0", false, false),
+		},
+		"/repos/acme/lib/tags": []map[string]any{
+			tag("nightly"), tag("v2.0.0-rc.1"), tag("v1.1.1"), tag("v0.9.0"), tag("v1.2.0"),
+			tag("v1.1.2"), tag("v1.1.0"), tag("v1.0.1"), tag("v1.0.0"),
+		},
+	}
+
+	for _, name := range []string{"v1.2.0", "v1.1.2", "v1.1.1", "v1.0.1", "v0.9.0", "nightly"} {
+		responses["/repos/acme/lib/commits/sha-"+name] = map[string]any{
+			"author": nil,
+			"commit": map[string]any{
+				"author": map[string]any{"name": "bob", "date": "2020-01-01T00:00:00Z"},
+			},
+		}
+	}
+
+	client, commits := serve(t, responses)
+
+	since := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Now()
+
+	updates, err := client.RetrieveUpdates(context.Background(), "https://github.com/acme/lib", since)
+	require.NoError(t, err)
+
+	type expectation struct {
+		kind   models.Kind
+		title  string
+		labels []string
+	}
+
+	expected := map[string]expectation{
+		"release:3":  {models.KindRelease, "v2.0.0-rc.1", []string{models.LabelMajor, models.LabelPrerelease}},
+		"release:2":  {models.KindRelease, "v1.1.0", []string{models.LabelMinor}},
+		"release:1":  {models.KindRelease, "v1.0.0", []string{models.LabelMajor}},
+		"tag:v1.2.0": {models.KindTag, "tag v1.2.0", []string{models.LabelMinor}},
+		"tag:v1.1.2": {models.KindTag, "tag v1.1.2", []string{models.LabelPatch}},
+		"tag:v1.1.1": {models.KindTag, "tag v1.1.1", []string{models.LabelPatch}},
+		"tag:v1.0.1": {models.KindTag, "tag v1.0.1", []string{models.LabelPatch}},
+		"tag:v0.9.0": {models.KindTag, "tag v0.9.0", []string{models.LabelMajor}},
+	}
+
+	ids := make([]string, 0, len(updates))
+
+	for _, update := range updates {
+		ids = append(ids, update.ID)
+
+		want, ok := expected[update.ID]
+		require.True(t, ok, update.ID)
+		require.Equal(t, want.kind, update.Kind, update.ID)
+		require.Equal(t, want.title, update.Title, update.ID)
+		require.Equal(t, want.labels, update.Labels, update.ID)
+
+		if update.Kind == models.KindTag {
+			require.Equal(t, "bob", update.Author)
+			require.False(t, update.CreatedAt.Before(before), "tags are dated by the poll, not the commit")
+		} else {
+			require.Equal(t, "notes for "+update.Title, update.Body)
+		}
+	}
+
+	// Drafts are skipped, and only the newest versions among the unreleased tags are dated.
+	require.Equal(t, []string{
+		"release:3", "release:2", "release:1",
+		"tag:v1.2.0", "tag:v1.1.2", "tag:v1.1.1", "tag:v1.0.1", "tag:v0.9.0",
+	}, ids)
+	require.EqualValues(t, 5, commits.Load())
+
+	_, err = client.RetrieveUpdates(context.Background(), "https://github.com/acme/lib", since)
+	require.NoError(t, err)
+	require.EqualValues(t, 5, commits.Load(), "tag commits are cached between polls")
+}
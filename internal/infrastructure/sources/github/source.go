@@ -0,0 +1,131 @@
+package github
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/es-debug/backend-academy-2024-go-template/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/sources"
+)
+
+const host = "github.com"
+
+func init() {
+	sources.Register(Source{})
+}
+
+type Source struct{}
+
+func (Source) Name() string { return config.GitHub }
+
+func (Source) Match(u *url.URL) bool {
+	h := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	return h == host || h == "www."+host
+}
+
+func (Source) Validate(u *url.URL) error {
+	_, err := locate(u)
+	return err
+}
+
+// Canonical lowercases the owner and repository, which GitHub matches
+// case-insensitively, and keeps the branch and path as typed.
+func (Source) Canonical(u *url.URL) string {
+	t, _ := locate(u)
+
+	path := "/" + strings.ToLower(t.owner) + "/" + strings.ToLower(t.repo)
+
+	if t.branch != "" {
+		path += "/tree/" + t.branch
+	}
+
+	if t.number != "" {
+		path += "/" + t.thread + "/" + t.number
+	}
+
+	if t.path != "" {
+		path += "/" + t.path
+	}
+
+	if t.workflow != "" {
+		path += "/actions/workflows/" + t.workflow
+	}
+
+	canonical := url.URL{
+		Scheme: config.SchemeSecure,
+		Host:   host,
+		Path:   path,
+	}
+
+	return canonical.String()
+}
+
+func (Source) NewClient(cfg *config.Config) sources.Client {
+	return NewGithubClient(cfg)
+}
+
+const (
+	threadIssue = "issues"
+	threadPull  = "pull"
+)
+
+// target is what a GitHub link points at: a whole repository, the commits
+// of one branch, optionally narrowed to a directory or a file, a single
+// issue or pull request thread, or an Actions workflow.
+type target struct {
+	owner    string
+	repo     string
+	branch   string
+	path     string
+	thread   string
+	number   string
+	workflow string
+}
+
+// locate reads owner/repo, owner/repo/tree/<branch>[/<path>],
+// owner/repo/{issues,pull}/<n> and owner/repo/actions/workflows/<file> links. Blob links to single files are read like
+// tree ones, and tabs of a thread such as /pull/<n>/files like the thread itself.
+// Branch names containing a slash are not told apart from the path that follows them.
+func locate(u *url.URL) (target, error) {
+	parts := segments(u)
+	if len(parts) < 2 {
+		return target{}, sources.ErrInvalidPath
+	}
+
+	t := target{
+		owner: parts[0],
+		repo:  strings.TrimSuffix(parts[1], ".git"),
+	}
+
+	switch {
+	case len(parts) == 2:
+	case len(parts) >= 4 && (parts[2] == "tree" || parts[2] == "blob"):
+		t.branch = parts[3]
+		t.path = strings.Join(parts[4:], "/")
+	case len(parts) >= 4 && (parts[2] == threadIssue || parts[2] == threadPull || parts[2] == "pulls"):
+		if !isNumber(parts[3]) {
+			return target{}, sources.ErrInvalidPath
+		}
+
+		t.thread = threadIssue
+		if parts[2] != threadIssue {
+			t.thread = threadPull
+		}
+
+		t.number = parts[3]
+	case len(parts) == 5 && parts[2] == "actions" && parts[3] == "workflows":
+		t.workflow = parts[4]
+	default:
+		return target{}, sources.ErrInvalidPath
+	}
+
+	return t, nil
+}
+
+func segments(u *url.URL) []string {
+	return strings.FieldsFunc(u.Path, func(r rune) bool { return r == '/' })
+}
+
+func isNumber(s string) bool {
+	return s != "" && strings.Trim(s, "0123456789") == ""
+}
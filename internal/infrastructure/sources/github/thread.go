@@ -0,0 +1,318 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+	"resty.dev/v3"
+)
+
+const threadPageSize = "100"
+
+// maxThreadPages bounds the pages of events or reviews read per poll.
+const maxThreadPages = 10
+
+type GitHubThread struct {
+	Title   string `json:"title"`
+	HTMLURL string `json:"html_url"`
+}
+
+type GitHubComment struct {
+	ID      int64  `json:"id"`
+	Body    string `json:"body"`
+	HTMLURL string `json:"html_url"`
+	User    struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type GitHubReview struct {
+	ID      int64  `json:"id"`
+	State   string `json:"state"`
+	Body    string `json:"body"`
+	HTMLURL string `json:"html_url"`
+	User    struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+type GitHubEvent struct {
+	ID    int64  `json:"id"`
+	Event string `json:"event"`
+	Actor *struct {
+		Login string `json:"login"`
+	} `json:"actor"`
+	Label *struct {
+		Name string `json:"name"`
+	} `json:"label"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// retrieveThread reports the activity on one issue or pull request: comments,
+// review comments and reviews, label changes and state transitions.
+func (g *GitHubClient) retrieveThread(
+	ctx context.Context,
+	repo map[string]string,
+	t target,
+	since time.Time,
+) ([]models.Update, error) {
+	params := map[string]string{"number": t.number}
+	for key, value := range repo {
+		params[key] = value
+	}
+
+	var thread GitHubThread
+	if _, err := g.client.R().
+		SetContext(ctx).
+		SetPathParams(params).
+		SetResult(&thread).
+		Get("{owner}/{repo}/issues/{number}"); err != nil {
+		return nil, fmt.Errorf("github client: failed to fetch thread")
+	}
+
+	var comments []GitHubComment
+	if _, err := g.client.R().
+		SetContext(ctx).
+		SetPathParams(params).
+		SetQueryParams(map[string]string{
+			"since":    since.UTC().Format(time.RFC3339),
+			"per_page": threadPageSize,
+		}).
+		SetResult(&comments).
+		Get("{owner}/{repo}/issues/{number}/comments"); err != nil {
+		return nil, fmt.Errorf("github client: failed to fetch comments updates")
+	}
+
+	events, err := latestPages(ctx, g, params, "{owner}/{repo}/issues/{number}/events", since,
+		func(event GitHubEvent) time.Time { return event.CreatedAt })
+	if err != nil {
+		return nil, fmt.Errorf("github client: failed to fetch events updates")
+	}
+
+	var (
+		reviewComments []GitHubComment
+		reviews        []GitHubReview
+	)
+
+	if t.thread == threadPull {
+		if _, err := g.client.R().
+			SetContext(ctx).
+			SetPathParams(params).
+			SetQueryParams(map[string]string{
+				"since":    since.UTC().Format(time.RFC3339),
+				"per_page": threadPageSize,
+			}).
+			SetResult(&reviewComments).
+			Get("{owner}/{repo}/pulls/{number}/comments"); err != nil {
+			return nil, fmt.Errorf("github client: failed to fetch review comments updates")
+		}
+
+		reviews, err = latestPages(ctx, g, params, "{owner}/{repo}/pulls/{number}/reviews", since,
+			func(review GitHubReview) time.Time { return review.SubmittedAt })
+		if err != nil {
+			return nil, fmt.Errorf("github client: failed to fetch reviews updates")
+		}
+	}
+
+	updates := make([]models.Update, 0, len(comments)+len(events)+len(reviewComments)+len(reviews))
+
+	for _, comment := range comments {
+		updates = append(updates, models.NewUpdate(
+			"comment:"+strconv.FormatInt(comment.ID, 10),
+			models.KindComment,
+			"comment on "+thread.Title,
+			comment.HTMLURL,
+			comment.CreatedAt,
+			comment.User.Login,
+			comment.Body,
+		))
+	}
+
+	for _, comment := range reviewComments {
+		updates = append(updates, models.NewUpdate(
+			"review-comment:"+strconv.FormatInt(comment.ID, 10),
+			models.KindReviewComment,
+			"review comment on "+thread.Title,
+			comment.HTMLURL,
+			comment.CreatedAt,
+			comment.User.Login,
+			comment.Body,
+		))
+	}
+
+	for _, review := range reviews {
+		// Pending reviews are drafts that nobody else can see yet.
+		if review.SubmittedAt.IsZero() {
+			continue
+		}
+
+		updates = append(updates, models.NewUpdate(
+			"review:"+strconv.FormatInt(review.ID, 10),
+			models.KindReview,
+			strings.ToLower(strings.ReplaceAll(review.State, "_", " "))+" review on "+thread.Title,
+			review.HTMLURL,
+			review.SubmittedAt,
+			review.User.Login,
+			review.Body,
+		))
+	}
+
+	return append(updates, eventUpdates(thread, events)...), nil
+}
+
+// eventUpdates keeps label changes and state transitions. A merge also closes
+// the pull request, only the merge is reported then.
+func eventUpdates(thread GitHubThread, events []GitHubEvent) []models.Update {
+	merged := make(map[int64]struct{})
+
+	for _, event := range events {
+		if event.Event == "merged" {
+			merged[event.CreatedAt.Unix()] = struct{}{}
+		}
+	}
+
+	updates := make([]models.Update, 0, len(events))
+
+	for _, event := range events {
+		var (
+			kind   models.Kind
+			title  string
+			labels []string
+		)
+
+		switch event.Event {
+		case "labeled", "unlabeled":
+			if event.Label == nil {
+				continue
+			}
+
+			kind, labels = models.KindLabel, []string{event.Label.Name}
+
+			if event.Event == "labeled" {
+				title = "label " + event.Label.Name + " added to " + thread.Title
+			} else {
+				title = "label " + event.Label.Name + " removed from " + thread.Title
+			}
+		case "closed", "reopened", "merged":
+			if _, ok := merged[event.CreatedAt.Unix()]; ok && event.Event == "closed" {
+				continue
+			}
+
+			kind, title = models.KindState, thread.Title+" "+event.Event
+		default:
+			continue
+		}
+
+		actor := ""
+		if event.Actor != nil {
+			actor = event.Actor.Login
+		}
+
+		updates = append(updates, models.NewUpdate(
+			"event:"+strconv.FormatInt(event.ID, 10),
+			kind,
+			title,
+			thread.HTMLURL,
+			event.CreatedAt,
+			actor,
+			"",
+			labels...,
+		))
+	}
+
+	return updates
+}
+
+// latestPages fetches the tail of a list GitHub returns oldest first and cannot
+// filter by date. It reads the pages from the last one backwards and stops at the
+// first page that reaches before since, or after maxThreadPages pages.
+func latestPages[T any](
+	ctx context.Context,
+	g *GitHubClient,
+	params map[string]string,
+	path string,
+	since time.Time,
+	date func(T) time.Time,
+) ([]T, error) {
+	page := func(number int) ([]T, *resty.Response, error) {
+		var items []T
+
+		res, err := g.client.R().
+			SetContext(ctx).
+			SetPathParams(params).
+			SetQueryParams(map[string]string{
+				"per_page": threadPageSize,
+				"page":     strconv.Itoa(number),
+			}).
+			SetResult(&items).
+			Get(path)
+
+		return items, res, err
+	}
+
+	first, res, err := page(1)
+	if err != nil {
+		return nil, err
+	}
+
+	last := lastPage(res.Header().Get("Link"))
+	if last <= 1 {
+		return first, nil
+	}
+
+	older := func(item T) bool {
+		at := date(item)
+		return !at.IsZero() && at.Before(since)
+	}
+
+	var items []T
+
+	for number := last; number > max(last-maxThreadPages, 0); number-- {
+		current := first
+		if number > 1 {
+			if current, _, err = page(number); err != nil {
+				return nil, err
+			}
+		}
+
+		items = append(current, items...)
+
+		if slices.ContainsFunc(current, older) {
+			break
+		}
+	}
+
+	return items, nil
+}
+
+// lastPage extracts the page number of the rel="last" link of a Link header.
+func lastPage(header string) int {
+	for _, part := range strings.Split(header, ",") {
+		link, rel, found := strings.Cut(part, ";")
+		if !found || !strings.Contains(rel, `rel="last"`) {
+			continue
+		}
+
+		u, err := url.Parse(strings.Trim(strings.TrimSpace(link), "<>"))
+		if err != nil {
+			return 0
+		}
+
+		number, err := strconv.Atoi(u.Query().Get("page"))
+		if err != nil {
+			return 0
+		}
+
+		return number
+	}
+
+	return 0
+}
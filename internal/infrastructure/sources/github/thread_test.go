@@ -0,0 +1,183 @@
+package github_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/sources/github"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetrieveThread(t *testing.T) {
+	since := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	merge := "2025-06-03T12:00:00Z"
+
+	event := func(id int64, kind, at, label string) map[string]any {
+		e := map[string]any{"id": id, "event": kind, "created_at": at, "actor": map[string]string{"login": "alice"}}
+		if label != "" {
+			e["label"] = map[string]string{"name": label}
+		}
+
+		return e
+	}
+
+	// Events are listed oldest first over three pages, only the last two reach past since.
+	events := map[string][]map[string]any{
+		"1": {event(1, "labeled", "2025-05-01T00:00:00Z", "old")},
+		"2": {
+			event(2, "labeled", "2025-05-31T00:00:00Z", "stale"),
+			event(3, "labeled", "2025-06-02T00:00:00Z", "bug"),
+		},
+		"3": {
+			event(4, "unlabeled", "2025-06-02T01:00:00Z", "bug"),
+			event(5, "subscribed", "2025-06-02T02:00:00Z", ""),
+			event(6, "merged", merge, ""),
+			event(7, "closed", merge, ""),
+			event(8, "reopened", "2025-06-04T00:00:00Z", ""),
+		},
+	}
+
+	var (
+		mu    sync.Mutex
+		pages []string
+	)
+
+	var server *httptest.Server
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response any
+
+		switch r.URL.Path {
+		case "/repos/acme/lib/issues/7":
+			response = map[string]any{"title": "Add cache", "html_url": "https://github.com/acme/lib/pull/7"}
+		case "/repos/acme/lib/issues/7/comments":
+			response = []map[string]any{{
+				"id": 10, "body": "looks good", "html_url": "https://comment/10",
+				"user": map[string]string{"login": "bob"}, "created_at": "2025-06-02T00:00:00Z",
+			}}
+		case "/repos/acme/lib/pulls/7/comments":
+			response = []map[string]any{{
+				"id": 11, "body": "nit", "html_url": "https://comment/11",
+				"user": map[string]string{"login": "carol"}, "created_at": "2025-06-02T00:00:00Z",
+			}}
+		case "/repos/acme/lib/pulls/7/reviews":
+			response = []map[string]any{
+				{"id": 12, "state": "CHANGES_REQUESTED", "user": map[string]string{"login": "carol"},
+					"submitted_at": "2025-06-02T00:00:00Z"},
+				{"id": 13, "state": "PENDING", "user": map[string]string{"login": "dave"}},
+			}
+		case "/repos/acme/lib/issues/7/events":
+			page := r.URL.Query().Get("page")
+			require.Equal(t, "100", r.URL.Query().Get("per_page"))
+
+			mu.Lock()
+			pages = append(pages, page)
+			mu.Unlock()
+
+			w.Header().Set("Link", fmt.Sprintf(
+				`<%[1]s/repos/acme/lib/issues/7/events?per_page=100&page=2>; rel="next", `+
+					`<%[1]s/repos/acme/lib/issues/7/events?per_page=100&page=3>; rel="last"`, server.URL))
+			response = events[page]
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{}
+	cfg.GitHub.BaseURL = server.URL
+
+	client := github.NewGithubClient(cfg)
+	defer client.Close()
+
+	updates, err := client.RetrieveUpdates(context.Background(), "https://github.com/acme/lib/pull/7", since)
+	require.NoError(t, err)
+
+	// The first page is read for its Link header, then the walk goes back from the last
+	// page and stops at the second one, which already reaches before since.
+	require.Equal(t, []string{"1", "3", "2"}, pages)
+
+	titles := make(map[string]string, len(updates))
+	kinds := make(map[string]models.Kind, len(updates))
+
+	for _, update := range updates {
+		titles[update.ID] = update.Title
+		kinds[update.ID] = update.Kind
+	}
+
+	require.Equal(t, map[string]string{
+		"comment:10":        "comment on Add cache",
+		"review-comment:11": "review comment on Add cache",
+		"review:12":         "changes requested review on Add cache",
+		"event:2":           "label stale added to Add cache",
+		"event:3":           "label bug added to Add cache",
+		"event:4":           "label bug removed from Add cache",
+		"event:6":           "Add cache merged",
+		"event:8":           "Add cache reopened",
+	}, titles)
+
+	require.Equal(t, models.KindLabel, kinds["event:3"])
+	require.Equal(t, models.KindState, kinds["event:6"])
+	require.Equal(t, models.KindReview, kinds["review:12"])
+	require.Equal(t, models.KindReviewComment, kinds["review-comment:11"])
+
+	ids := make([]string, 0, len(updates))
+	for _, update := range updates {
+		ids = append(ids, update.ID)
+	}
+
+	require.False(t, slices.Contains(ids, "event:7"), "the close that comes with a merge is not reported")
+}
+
+func TestRetrieveThreadSinglePage(t *testing.T) {
+	var requests int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response any = []any{}
+
+		switch r.URL.Path {
+		case "/repos/acme/lib/issues/3":
+			response = map[string]any{"title": "Crash", "html_url": "https://github.com/acme/lib/issues/3"}
+		case "/repos/acme/lib/issues/3/events":
+			requests++
+			require.Equal(t, "1", r.URL.Query().Get("page"))
+
+			response = []map[string]any{
+				{"id": 1, "event": "closed", "created_at": "2025-06-02T00:00:00Z"},
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{}
+	cfg.GitHub.BaseURL = server.URL
+
+	client := github.NewGithubClient(cfg)
+	defer client.Close()
+
+	updates, err := client.RetrieveUpdates(context.Background(), "https://github.com/acme/lib/issues/3",
+		time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Equal(t, 1, requests)
+
+	require.Len(t, updates, 1)
+	require.Equal(t, "event:1", updates[0].ID)
+	require.Equal(t, "Crash closed", updates[0].Title)
+	require.Empty(t, updates[0].Author)
+}
@@ -0,0 +1,125 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+)
+
+type GitHubRepository struct {
+	DefaultBranch string `json:"default_branch"`
+}
+
+type GitHubWorkflowRuns struct {
+	WorkflowRuns []GitHubWorkflowRun `json:"workflow_runs"`
+}
+
+type GitHubWorkflowRun struct {
+	ID         int64     `json:"id"`
+	Name       string    `json:"name"`
+	RunNumber  int64     `json:"run_number"`
+	HTMLURL    string    `json:"html_url"`
+	Conclusion string    `json:"conclusion"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	Actor      struct {
+		Login string `json:"login"`
+	} `json:"actor"`
+	HeadCommit struct {
+		Message string `json:"message"`
+	} `json:"head_commit"`
+}
+
+// Cancelled and skipped runs say nothing about the health of the branch.
+var runOutcomes = map[string]bool{
+	"success":         true,
+	"failure":         false,
+	"timed_out":       false,
+	"startup_failure": false,
+}
+
+// retrieveWorkflowRuns reports the completed runs of a workflow on the default branch
+// that turned it from green to red or back. The oldest run of the page has nothing
+// to be compared with and is never reported.
+func (g *GitHubClient) retrieveWorkflowRuns(
+	ctx context.Context,
+	repo map[string]string,
+	t target,
+) ([]models.Update, error) {
+	var repository GitHubRepository
+	if _, err := g.client.R().
+		SetContext(ctx).
+		SetPathParams(repo).
+		SetResult(&repository).
+		Get("{owner}/{repo}"); err != nil {
+		return nil, fmt.Errorf("github client: failed to fetch repository")
+	}
+
+	var runs GitHubWorkflowRuns
+	if _, err := g.client.R().
+		SetContext(ctx).
+		SetPathParams(repo).
+		SetPathParam("workflow", t.workflow).
+		SetQueryParams(map[string]string{
+			"branch":   repository.DefaultBranch,
+			"status":   "completed",
+			"per_page": pageSize,
+		}).
+		SetResult(&runs).
+		Get("{owner}/{repo}/actions/workflows/{workflow}/runs"); err != nil {
+		return nil, fmt.Errorf("github client: failed to fetch workflow runs updates")
+	}
+
+	return transitions(runs.WorkflowRuns, repository.DefaultBranch), nil
+}
+
+func transitions(runs []GitHubWorkflowRun, branch string) []models.Update {
+	runs = slices.Clone(runs)
+
+	// The API lists the newest runs first.
+	slices.SortFunc(runs, func(a, b GitHubWorkflowRun) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	var (
+		updates []models.Update
+		last    *bool
+	)
+
+	for _, run := range runs {
+		passed, ok := runOutcomes[run.Conclusion]
+		if !ok {
+			continue
+		}
+
+		if last != nil && *last != passed {
+			title := run.Name + " failed on " + branch
+			if passed {
+				title = run.Name + " recovered on " + branch
+			}
+
+			subject, _, _ := strings.Cut(run.HeadCommit.Message, "\n")
+
+			// A run is reported when it completed, which is its last update.
+			updates = append(updates, models.NewUpdate(
+				"run:"+strconv.FormatInt(run.ID, 10),
+				models.KindWorkflow,
+				title+" (#"+strconv.FormatInt(run.RunNumber, 10)+")",
+				run.HTMLURL,
+				run.UpdatedAt,
+				run.Actor.Login,
+				strings.TrimSpace(subject),
+				run.Conclusion,
+			))
+		}
+
+		last = &passed
+	}
+
+	return updates
+}
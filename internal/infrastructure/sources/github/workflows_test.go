@@ -0,0 +1,100 @@
+package github_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/sources/github"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetrieveWorkflowRuns(t *testing.T) {
+	run := func(id int64, conclusion, at string) map[string]any {
+		return map[string]any{
+			"id": id, "name": "CI", "run_number": id, "html_url": "https://run", "conclusion": conclusion,
+			"created_at": at, "updated_at": at, "actor": map[string]string{"login": "alice"},
+			"head_commit": map[string]string{"message": "Change things\n\nDetails"},
+		}
+	}
+
+	tests := map[string]struct {
+		runs     []map[string]any
+		expected []string
+	}{
+		"failure then recovery": {
+			runs: []map[string]any{
+				run(5, "success", "2025-06-05T00:00:00Z"),
+				run(4, "cancelled", "2025-06-04T00:00:00Z"),
+				run(3, "failure", "2025-06-03T00:00:00Z"),
+				run(2, "success", "2025-06-02T00:00:00Z"),
+				run(1, "success", "2025-06-01T00:00:00Z"),
+			},
+			expected: []string{"CI failed on main (#3)", "CI recovered on main (#5)"},
+		},
+		"still failing": {
+			runs: []map[string]any{
+				run(3, "timed_out", "2025-06-03T00:00:00Z"),
+				run(2, "failure", "2025-06-02T00:00:00Z"),
+				run(1, "success", "2025-06-01T00:00:00Z"),
+			},
+			expected: []string{"CI failed on main (#2)"},
+		},
+		"oldest run alone": {
+			runs: []map[string]any{
+				run(1, "failure", "2025-06-01T00:00:00Z"),
+			},
+			expected: []string{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var response any
+
+				switch r.URL.Path {
+				case "/repos/acme/lib":
+					response = map[string]string{"default_branch": "main"}
+				case "/repos/acme/lib/actions/workflows/ci.yml/runs":
+					require.Equal(t, "main", r.URL.Query().Get("branch"))
+					require.Equal(t, "completed", r.URL.Query().Get("status"))
+
+					response = map[string]any{"workflow_runs": test.runs}
+				default:
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(response)
+			}))
+			defer server.Close()
+
+			cfg := &config.Config{}
+			cfg.GitHub.BaseURL = server.URL
+
+			client := github.NewGithubClient(cfg)
+			defer client.Close()
+
+			updates, err := client.RetrieveUpdates(context.Background(),
+				"https://github.com/acme/lib/actions/workflows/ci.yml", time.Time{})
+			require.NoError(t, err)
+
+			titles := make([]string, 0, len(updates))
+			for _, update := range updates {
+				require.Equal(t, models.KindWorkflow, update.Kind)
+				require.Equal(t, "Change things", update.Body)
+
+				titles = append(titles, update.Title)
+			}
+
+			require.Equal(t, test.expected, titles)
+		})
+	}
+}
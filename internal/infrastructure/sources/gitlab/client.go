@@ -0,0 +1,236 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+	"resty.dev/v3"
+)
+
+type GitLabClient struct {
+	client *resty.Client
+	source *Source
+	token  string
+}
+
+func NewGitLabClient(cfg *config.Config, source *Source) *GitLabClient {
+	cb := resty.NewCircuitBreaker().
+		SetTimeout(cfg.CircuitBreakerPolicy.Timeout).
+		SetFailureThreshold(cfg.CircuitBreakerPolicy.FailureThreshold).
+		SetSuccessThreshold(cfg.CircuitBreakerPolicy.MaxRequests)
+
+	client := resty.New().
+		SetTimeout(cfg.TimeoutPolicy.ClientOverall).
+		SetRetryCount(int(cfg.RetryPolicy.Attempts)).
+		SetRetryWaitTime(cfg.RetryPolicy.Delay).
+		AddRetryConditions(func(res *resty.Response, err error) bool {
+			return !slices.Contains(cfg.RetryPolicy.StatusCodes, res.StatusCode())
+		}).
+		SetCircuitBreaker(cb)
+
+	return &GitLabClient{
+		client: client,
+		source: source,
+		token:  cfg.Secrets.GitLabToken,
+	}
+}
+
+type GitLabUpdate struct {
+	ID          int64    `json:"id"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	WebURL      string   `json:"web_url"`
+	Labels      []string `json:"labels"`
+	Author      struct {
+		Username string `json:"username"`
+	} `json:"author"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (g *GitLabUpdate) update(prefix string, kind models.Kind) models.Update {
+	update := models.NewUpdate(
+		prefix+strconv.FormatInt(g.ID, 10),
+		kind,
+		g.Title,
+		g.WebURL,
+		g.CreatedAt,
+		g.Author.Username,
+		g.Description,
+		g.Labels...,
+	)
+	update.UpdatedAt = g.UpdatedAt
+
+	return update
+}
+
+type GitLabEvent struct {
+	TargetTitle string `json:"target_title"`
+	Note        *struct {
+		ID           int64  `json:"id"`
+		Body         string `json:"body"`
+		NoteableType string `json:"noteable_type"`
+		NoteableIID  int64  `json:"noteable_iid"`
+		Author       struct {
+			Username string `json:"username"`
+		} `json:"author"`
+		CreatedAt time.Time `json:"created_at"`
+	} `json:"note"`
+}
+
+type GitLabTag struct {
+	Name    string `json:"name"`
+	Message string `json:"message"`
+	Commit  struct {
+		AuthorName string    `json:"author_name"`
+		CreatedAt  time.Time `json:"created_at"`
+	} `json:"commit"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+func (g *GitLabClient) RetrieveUpdates(ctx context.Context, link string, since time.Time) ([]models.Update, error) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return nil, fmt.Errorf("gitlab client: failed to parse link")
+	}
+
+	base, project, err := g.source.locate(u)
+	if err != nil {
+		return nil, err
+	}
+
+	api := base.JoinPath("api", "v4", "projects").String() + "/" + url.PathEscape(project)
+	web := base.JoinPath(project).String()
+
+	request := func() *resty.Request {
+		req := g.client.R().SetContext(ctx)
+
+		// The token belongs to the configured instance and is never sent to another GitLab.
+		if base == g.source.instance && g.token != "" {
+			req.SetHeader("PRIVATE-TOKEN", g.token)
+		}
+
+		return req
+	}
+
+	created := map[string]string{
+		"created_after": since.UTC().Format(time.RFC3339),
+		"order_by":      "created_at",
+		"sort":          "desc",
+	}
+
+	var merges []GitLabUpdate
+	if _, err := request().
+		SetQueryParams(created).
+		SetResult(&merges).
+		Get(api + "/merge_requests"); err != nil {
+		return nil, fmt.Errorf("gitlab client: failed to fetch merge requests updates")
+	}
+
+	var issues []GitLabUpdate
+	if _, err := request().
+		SetQueryParams(created).
+		SetResult(&issues).
+		Get(api + "/issues"); err != nil {
+		return nil, fmt.Errorf("gitlab client: failed to fetch issues updates")
+	}
+
+	// Events can only be narrowed down to a day, the cursor drops what is older.
+	var events []GitLabEvent
+	if _, err := request().
+		SetQueryParams(map[string]string{
+			"action": "commented",
+			"after":  since.UTC().AddDate(0, 0, -1).Format(time.DateOnly),
+			"sort":   "desc",
+		}).
+		SetResult(&events).
+		Get(api + "/events"); err != nil {
+		return nil, fmt.Errorf("gitlab client: failed to fetch notes updates")
+	}
+
+	var tags []GitLabTag
+	if _, err := request().
+		SetQueryParams(map[string]string{
+			"order_by": "updated",
+			"sort":     "desc",
+		}).
+		SetResult(&tags).
+		Get(api + "/repository/tags"); err != nil {
+		return nil, fmt.Errorf("gitlab client: failed to fetch tags updates")
+	}
+
+	updates := make([]models.Update, 0, len(merges)+len(issues)+len(events)+len(tags))
+
+	for _, merge := range merges {
+		updates = append(updates, merge.update("mr:", models.KindPR))
+	}
+
+	for _, issue := range issues {
+		updates = append(updates, issue.update("issue:", models.KindIssue))
+	}
+
+	for _, event := range events {
+		if event.Note == nil {
+			continue
+		}
+
+		note := event.Note
+
+		updates = append(updates, models.NewUpdate(
+			"note:"+strconv.FormatInt(note.ID, 10),
+			models.KindComment,
+			"comment on "+event.TargetTitle,
+			noteURL(web, note.NoteableType, note.NoteableIID, note.ID),
+			note.CreatedAt,
+			note.Author.Username,
+			note.Body,
+		))
+	}
+
+	for _, tag := range tags {
+		// Lightweight tags carry no date of their own, their commit's date stands in.
+		createdAt := tag.Commit.CreatedAt
+		if tag.CreatedAt != nil {
+			createdAt = *tag.CreatedAt
+		}
+
+		updates = append(updates, models.NewUpdate(
+			"tag:"+tag.Name,
+			models.KindTag,
+			"tag "+tag.Name,
+			web+"/-/tags/"+url.PathEscape(tag.Name),
+			createdAt,
+			tag.Commit.AuthorName,
+			tag.Message,
+		))
+	}
+
+	return updates, nil
+}
+
+func (g *GitLabClient) Close() error {
+	if err := g.client.Close(); err != nil {
+		return fmt.Errorf("gitlab client: failed to cleanup resources: %w", err)
+	}
+
+	return nil
+}
+
+func noteURL(web, noteableType string, iid, noteID int64) string {
+	anchor := "#note_" + strconv.FormatInt(noteID, 10)
+
+	switch noteableType {
+	case "Issue":
+		return web + "/-/issues/" + strconv.FormatInt(iid, 10) + anchor
+	case "MergeRequest":
+		return web + "/-/merge_requests/" + strconv.FormatInt(iid, 10) + anchor
+	}
+
+	return web
+}
@@ -0,0 +1,122 @@
+package gitlab
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/es-debug/backend-academy-2024-go-template/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/sources"
+)
+
+const host = "gitlab.com"
+
+// separator starts the part of a GitLab path that addresses something inside the project.
+const separator = "-"
+
+func init() {
+	sources.Register(&Source{
+		instance: &url.URL{Scheme: config.SchemeSecure, Host: host},
+	})
+}
+
+// Source tracks projects on gitlab.com and on one configured, possibly self-hosted, instance.
+type Source struct {
+	instance *url.URL
+}
+
+func (s *Source) Name() string { return config.GitLab }
+
+func (s *Source) Configure(cfg *config.Config) error {
+	instance, err := url.Parse(strings.TrimSuffix(cfg.GitLab.BaseURL, "/"))
+	if err != nil {
+		return err
+	}
+
+	if instance.Host == "" {
+		return sources.ErrInvalidLink
+	}
+
+	instance.Host = strings.ToLower(instance.Host)
+	s.instance = instance
+
+	return nil
+}
+
+func (s *Source) Match(u *url.URL) bool {
+	_, ok := s.base(u)
+	return ok
+}
+
+func (s *Source) Validate(u *url.URL) error {
+	if _, _, err := s.locate(u); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Canonical lowercases the project path, which GitLab routes case-insensitively,
+// and keeps whatever follows the /-/ separator as typed.
+func (s *Source) Canonical(u *url.URL) string {
+	base, project, _ := s.locate(u)
+
+	path := base.Path + "/" + project
+
+	parts := segments(u.Path)
+	if i := slices.Index(parts, separator); i >= 0 {
+		path += "/" + strings.Join(parts[i:], "/")
+	}
+
+	canonical := url.URL{
+		Scheme: base.Scheme,
+		Host:   base.Host,
+		Path:   path,
+	}
+
+	return canonical.String()
+}
+
+func (s *Source) NewClient(cfg *config.Config) sources.Client {
+	return NewGitLabClient(cfg, s)
+}
+
+// base returns the GitLab the url lives on: the configured instance or gitlab.com.
+func (s *Source) base(u *url.URL) (*url.URL, bool) {
+	h := strings.TrimSuffix(strings.ToLower(u.Host), ".")
+
+	switch {
+	case h == s.instance.Host && strings.HasPrefix(u.Path+"/", s.instance.Path+"/"):
+		return s.instance, true
+	case h == host || h == "www."+host:
+		return &url.URL{Scheme: config.SchemeSecure, Host: host}, true
+	}
+
+	return nil, false
+}
+
+// locate splits a link into its GitLab and the lowercased full path of the project.
+func (s *Source) locate(u *url.URL) (*url.URL, string, error) {
+	base, ok := s.base(u)
+	if !ok {
+		return nil, "", sources.ErrUnknownSource
+	}
+
+	parts := segments(strings.TrimPrefix(u.Path, base.Path))
+	if i := slices.Index(parts, separator); i >= 0 {
+		parts = parts[:i]
+	}
+
+	// Projects live at least one group deep, groups may nest.
+	if len(parts) < 2 {
+		return nil, "", sources.ErrInvalidPath
+	}
+
+	parts[len(parts)-1] = strings.TrimSuffix(parts[len(parts)-1], ".git")
+
+	return base, strings.ToLower(strings.Join(parts, "/")), nil
+}
+
+func segments(path string) []string {
+	return strings.FieldsFunc(path, func(r rune) bool { return r == '/' })
+}
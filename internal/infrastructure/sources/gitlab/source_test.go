@@ -0,0 +1,129 @@
+package gitlab_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/sources/gitlab"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelfHostedInstance(t *testing.T) {
+	source := &gitlab.Source{}
+
+	cfg := &config.Config{}
+	cfg.GitLab.BaseURL = "https://git.example.com/gitlab/"
+	require.NoError(t, source.Configure(cfg))
+
+	tests := map[string]struct {
+		link     string
+		match    bool
+		expected string
+	}{
+		"project on the instance": {
+			link:     "https://Git.Example.com/gitlab/Team/Service/-/issues/7",
+			match:    true,
+			expected: "https://git.example.com/gitlab/team/service/-/issues/7",
+		},
+		"project on gitlab.com": {
+			link:     "https://www.gitlab.com/team/service",
+			match:    true,
+			expected: "https://gitlab.com/team/service",
+		},
+		"instance host outside its path": {
+			link:  "https://git.example.com/other/team/service",
+			match: false,
+		},
+		"another host": {
+			link:  "https://gitlab.example.org/team/service",
+			match: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			u, err := url.Parse(test.link)
+			require.NoError(t, err)
+
+			require.Equal(t, test.match, source.Match(u))
+
+			if test.match {
+				require.NoError(t, source.Validate(u))
+				require.Equal(t, test.expected, source.Canonical(u))
+			}
+		})
+	}
+}
+
+func TestRetrieveUpdates(t *testing.T) {
+	since := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	responses := map[string]any{
+		"/api/v4/projects/team%2Fservice/merge_requests": []map[string]any{{
+			"id": 1, "title": "Add cache", "web_url": "https://mr", "labels": []string{"backend"},
+			"author": map[string]string{"username": "alice"}, "created_at": "2025-06-02T10:00:00Z",
+		}},
+		"/api/v4/projects/team%2Fservice/issues": []map[string]any{{
+			"id": 2, "title": "Crash", "web_url": "https://issue",
+			"author": map[string]string{"username": "bob"}, "created_at": "2025-06-02T11:00:00Z",
+		}},
+		"/api/v4/projects/team%2Fservice/events": []map[string]any{{
+			"target_title": "Crash",
+			"note": map[string]any{
+				"id": 3, "body": "cannot reproduce", "noteable_type": "Issue", "noteable_iid": 9,
+				"author": map[string]string{"username": "carol"}, "created_at": "2025-06-02T12:00:00Z",
+			},
+		}},
+		"/api/v4/projects/team%2Fservice/repository/tags": []map[string]any{{
+			"name": "v1.0.0", "message": "first",
+			"commit": map[string]string{"author_name": "dave", "created_at": "2025-06-02T13:00:00Z"},
+		}},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		response, ok := responses[r.URL.EscapedPath()]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{}
+	cfg.GitLab.BaseURL = server.URL
+	cfg.Secrets.GitLabToken = "secret"
+
+	source := &gitlab.Source{}
+	require.NoError(t, source.Configure(cfg))
+
+	client := gitlab.NewGitLabClient(cfg, source)
+	defer client.Close()
+
+	updates, err := client.RetrieveUpdates(context.Background(), server.URL+"/team/service", since)
+	require.NoError(t, err)
+	require.Len(t, updates, 4)
+
+	kinds := make([]models.Kind, 0, len(updates))
+	for _, update := range updates {
+		kinds = append(kinds, update.Kind)
+	}
+
+	require.Equal(t, []models.Kind{models.KindPR, models.KindIssue, models.KindComment, models.KindTag}, kinds)
+	require.Equal(t, server.URL+"/team/service/-/issues/9#note_3", updates[2].URL)
+	require.Equal(t, time.Date(2025, 6, 2, 13, 0, 0, 0, time.UTC), updates[3].CreatedAt)
+}
@@ -0,0 +1,185 @@
+package stackoverflow
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/sources"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+	"resty.dev/v3"
+)
+
+type StackOverflowClient struct {
+	client *resty.Client
+}
+
+func NewStackOverflowClient(cfg *config.Config) *StackOverflowClient {
+	cb := resty.NewCircuitBreaker().
+		SetTimeout(5 * time.Second).
+		SetFailureThreshold(3).
+		SetSuccessThreshold(1)
+
+	client := resty.New().
+		SetBaseURL(strings.TrimSuffix(cfg.StackExchange.BaseURL, "/")+"/").
+		SetHeader("X-API-Access", cfg.Secrets.StackOverflowToken).
+		SetTimeout(cfg.TimeoutPolicy.ClientOverall).
+		SetRetryCount(int(cfg.RetryPolicy.Attempts)).
+		SetRetryWaitTime(cfg.RetryPolicy.Delay).
+		AddRetryConditions(func(res *resty.Response, err error) bool {
+			return !slices.Contains(cfg.RetryPolicy.StatusCodes, res.StatusCode())
+		}).
+		SetCircuitBreaker(cb)
+
+	return &StackOverflowClient{
+		client: client,
+	}
+}
+
+type StackOverflowUpdate struct {
+	AnswerID  int64  `json:"answer_id"`
+	CommentID int64  `json:"comment_id"`
+	Body      string `json:"body"`
+	Owner     struct {
+		Username string `json:"display_name"`
+	} `json:"owner"`
+	CreatedAt int64 `json:"creation_date"`
+}
+
+type StackOverflowUpdates struct {
+	Items []StackOverflowUpdate `json:"items"`
+}
+
+// RetrieveUpdates reports the activity on the link without a previous score,
+// so no score milestone is announced.
+func (s *StackOverflowClient) RetrieveUpdates(ctx context.Context, link string, since time.Time) ([]models.Update, error) {
+	updates, _, err := s.RetrieveScoredUpdates(ctx, link, since, nil)
+	return updates, err
+}
+
+// RetrieveScoredUpdates reports the activity on a question and returns its score,
+// announcing the milestones crossed since the given previous score. Tag feeds have
+// no score of their own and hand the previous one back.
+func (s *StackOverflowClient) RetrieveScoredUpdates(
+	ctx context.Context,
+	link string,
+	since time.Time,
+	previous *int64,
+) ([]models.Update, *int64, error) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return nil, nil, fmt.Errorf("stackoverflow client: failed to parse link")
+	}
+
+	t, err := locate(u)
+	if err != nil {
+		return nil, nil, sources.ErrInvalidPath
+	}
+
+	if len(t.tags) > 0 {
+		updates, err := s.retrieveQuestions(ctx, t, since, time.Now())
+		return updates, previous, err
+	}
+
+	questionID := t.question
+
+	params := map[string]string{
+		"order":    "desc",
+		"sort":     "creation",
+		"site":     t.site,
+		"filter":   "withbody",
+		"fromdate": strconv.FormatInt(since.Unix(), 10),
+	}
+
+	var questions StackOverflowQuestions
+	if _, err := s.client.R().
+		SetContext(ctx).
+		SetPathParam("questionID", questionID).
+		SetQueryParam("site", t.site).
+		SetResult(&questions).
+		Get("questions/{questionID}"); err != nil || len(questions.Items) == 0 {
+		return nil, nil, fmt.Errorf("stackoverflow client: failed to fetch question")
+	}
+
+	question := questions.Items[0]
+	title := html.UnescapeString(question.Title)
+
+	var answers StackOverflowUpdates
+	if _, err := s.client.R().
+		SetContext(ctx).
+		SetPathParam("questionID", questionID).
+		SetQueryParams(params).
+		SetResult(&answers).
+		Get("questions/{questionID}/answers"); err != nil {
+		return nil, nil, fmt.Errorf("stackoverflow client: failed to fetch answers updates")
+	}
+
+	var comments StackOverflowUpdates
+	if _, err := s.client.R().
+		SetContext(ctx).
+		SetPathParam("questionID", questionID).
+		SetQueryParams(params).
+		SetResult(&comments).
+		Get("questions/{questionID}/comments"); err != nil {
+		return nil, nil, fmt.Errorf("stackoverflow client: failed to fetch comments updates")
+	}
+
+	var timeline StackOverflowTimeline
+	if _, err := s.client.R().
+		SetContext(ctx).
+		SetPathParam("questionID", questionID).
+		SetQueryParams(map[string]string{
+			"site":     t.site,
+			"fromdate": strconv.FormatInt(since.Unix(), 10),
+		}).
+		SetResult(&timeline).
+		Get("questions/{questionID}/timeline"); err != nil {
+		return nil, nil, fmt.Errorf("stackoverflow client: failed to fetch timeline updates")
+	}
+
+	updates := make([]models.Update, 0, len(answers.Items)+len(comments.Items)+len(timeline.Items)+2)
+
+	for _, answer := range answers.Items {
+		updates = append(updates, models.NewUpdate(
+			"answer:"+strconv.FormatInt(answer.AnswerID, 10),
+			models.KindAnswer,
+			"answer to "+title,
+			"https://"+t.site+"/a/"+strconv.FormatInt(answer.AnswerID, 10),
+			time.Unix(answer.CreatedAt, 0).UTC(),
+			answer.Owner.Username,
+			answer.Body,
+		))
+	}
+
+	for _, comment := range comments.Items {
+		updates = append(updates, models.NewUpdate(
+			"comment:"+strconv.FormatInt(comment.CommentID, 10),
+			models.KindComment,
+			"comment on "+title,
+			fmt.Sprintf("https://%[1]s/questions/%[2]s#comment%[3]d_%[2]s", t.site, questionID, comment.CommentID),
+			time.Unix(comment.CreatedAt, 0).UTC(),
+			comment.Owner.Username,
+			comment.Body,
+		))
+	}
+
+	updates = append(updates, timelineUpdates(t, question, timeline.Items)...)
+
+	updates = append(updates, questionUpdates(t, question, previous, time.Now())...)
+
+	return updates, &question.Score, nil
+}
+
+func (s *StackOverflowClient) Close() error {
+	if err := s.client.Close(); err != nil {
+		return fmt.Errorf("stackoverflow client: failed to cleanup resources: %w", err)
+	}
+
+	return nil
+}
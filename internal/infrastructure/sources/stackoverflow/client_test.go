@@ -0,0 +1,69 @@
+package stackoverflow_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetrieveUpdatesSite(t *testing.T) {
+	tests := map[string]struct {
+		link     string
+		site     string
+		expected []string
+	}{
+		"localised stackoverflow": {
+			link:     "https://ru.stackoverflow.com/questions/42/slug",
+			site:     "ru.stackoverflow.com",
+			expected: []string{"https://ru.stackoverflow.com/a/7", "https://ru.stackoverflow.com/questions/42#comment9_42"},
+		},
+		"meta site": {
+			link:     "https://meta.serverfault.com/q/42",
+			site:     "meta.serverfault.com",
+			expected: []string{"https://meta.serverfault.com/a/7", "https://meta.serverfault.com/questions/42#comment9_42"},
+		},
+		"stackexchange network site": {
+			link:     "https://math.stackexchange.com/questions/42",
+			site:     "math.stackexchange.com",
+			expected: []string{"https://math.stackexchange.com/a/7", "https://math.stackexchange.com/questions/42#comment9_42"},
+		},
+		"mobile host": {
+			link:     "https://m.superuser.com/questions/42",
+			site:     "superuser.com",
+			expected: []string{"https://superuser.com/a/7", "https://superuser.com/questions/42#comment9_42"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client, queries := stub(t, map[string]any{
+				"/questions/42": map[string]any{"items": []map[string]any{{"question_id": 42, "title": "Question"}}},
+				"/questions/42/answers": map[string]any{"items": []map[string]any{
+					{"answer_id": 7, "owner": map[string]string{"display_name": "alice"}},
+				}},
+				"/questions/42/comments": map[string]any{"items": []map[string]any{
+					{"comment_id": 9, "owner": map[string]string{"display_name": "bob"}},
+				}},
+			})
+
+			updates, err := client.RetrieveUpdates(context.Background(), test.link, time.Now().Add(-time.Hour))
+			require.NoError(t, err)
+
+			require.Contains(t, queries, "/questions/42/answers")
+			require.Contains(t, queries, "/questions/42/comments")
+
+			for path, query := range queries {
+				require.Equal(t, test.site, query.Get("site"), path)
+			}
+
+			urls := make([]string, 0, len(updates))
+			for _, update := range updates {
+				urls = append(urls, update.URL)
+			}
+
+			require.Equal(t, test.expected, urls)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package stackoverflow
+
+import (
+	"html"
+	"strconv"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+)
+
+// scoreThresholds are the question scores worth a notification.
+var scoreThresholds = []int64{10, 25, 50, 100, 250, 500, 1000}
+
+type StackOverflowTimelineEvent struct {
+	TimelineType string `json:"timeline_type"`
+	QuestionID   int64  `json:"question_id"`
+	PostID       int64  `json:"post_id"`
+	RevisionGUID string `json:"revision_guid"`
+	Detail       string `json:"detail"`
+	User         struct {
+		Username string `json:"display_name"`
+	} `json:"user"`
+	CreatedAt int64 `json:"creation_date"`
+}
+
+type StackOverflowTimeline struct {
+	Items []StackOverflowTimelineEvent `json:"items"`
+}
+
+// timelineUpdates keeps the edits of the question itself and answers
+// being accepted or losing acceptance.
+func timelineUpdates(t target, question StackOverflowQuestion, events []StackOverflowTimelineEvent) []models.Update {
+	title := html.UnescapeString(question.Title)
+	updates := make([]models.Update, 0, len(events))
+
+	for _, event := range events {
+		postID := strconv.FormatInt(event.PostID, 10)
+		createdAt := time.Unix(event.CreatedAt, 0).UTC()
+
+		switch event.TimelineType {
+		case "revision":
+			if event.PostID != question.QuestionID {
+				continue
+			}
+
+			updates = append(updates, models.NewUpdate(
+				"edit:"+event.RevisionGUID,
+				models.KindEdit,
+				title+" edited",
+				"https://"+t.site+"/posts/"+postID+"/revisions",
+				createdAt,
+				event.User.Username,
+				html.UnescapeString(event.Detail),
+			))
+		case "accepted_answer", "unaccepted_answer":
+			kind, verb := models.KindAccepted, " accepted"
+			if event.TimelineType == "unaccepted_answer" {
+				kind, verb = models.KindUnaccepted, " no longer accepted"
+			}
+
+			// An answer can be accepted again later, the date tells the events apart.
+			updates = append(updates, models.NewUpdate(
+				string(kind)+":"+postID+":"+strconv.FormatInt(event.CreatedAt, 10),
+				kind,
+				"answer to "+title+verb,
+				"https://"+t.site+"/a/"+postID,
+				createdAt,
+				event.User.Username,
+				"",
+			))
+		}
+	}
+
+	return updates
+}
+
+// questionUpdates reports the state the question is in now: an open bounty
+// and the highest score threshold crossed since the previous poll. Without a
+// previous score the question is seen for the first time and its score is only
+// recorded, so a question that is already popular announces nothing.
+func questionUpdates(t target, question StackOverflowQuestion, previous *int64, now time.Time) []models.Update {
+	title := html.UnescapeString(question.Title)
+	link := "https://" + t.site + "/questions/" + strconv.FormatInt(question.QuestionID, 10)
+	now = now.UTC().Truncate(time.Second)
+
+	var updates []models.Update
+
+	// The API does not tell when a bounty was started, so it is dated by the poll that saw it.
+	if question.BountyAmount > 0 && question.BountyClosesDate > 0 {
+		closes := time.Unix(question.BountyClosesDate, 0)
+
+		updates = append(updates, models.NewUpdate(
+			"bounty:"+strconv.FormatInt(question.BountyClosesDate, 10),
+			models.KindBounty,
+			"+"+strconv.FormatInt(question.BountyAmount, 10)+" bounty on "+title,
+			link,
+			now,
+			"",
+			"open until "+closes.UTC().Format(time.RFC1123),
+		))
+	}
+
+	if previous == nil {
+		return updates
+	}
+
+	var crossed int64
+
+	for _, threshold := range scoreThresholds {
+		if *previous < threshold && question.Score >= threshold {
+			crossed = threshold
+		}
+	}
+
+	if crossed > 0 {
+		score := strconv.FormatInt(crossed, 10)
+
+		updates = append(updates, models.NewUpdate(
+			"score:"+score,
+			models.KindScore,
+			title+" reached a score of "+score,
+			link,
+			now,
+			"",
+			"",
+			models.LabelScore+strconv.FormatInt(question.Score, 10),
+		))
+	}
+
+	return updates
+}
@@ -0,0 +1,156 @@
+package stackoverflow_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimelineUpdates(t *testing.T) {
+	client, _ := stub(t, map[string]any{
+		"/questions/42": map[string]any{"items": []map[string]any{
+			{"question_id": 42, "title": "Why &quot;nil&quot;?", "score": 3},
+		}},
+		"/questions/42/timeline": map[string]any{"items": []map[string]any{
+			{
+				"timeline_type": "revision", "post_id": 42, "revision_guid": "AB12", "detail": "edited body",
+				"user": map[string]string{"display_name": "alice"}, "creation_date": 1748800000,
+			},
+			{
+				"timeline_type": "revision", "post_id": 7, "revision_guid": "CD34",
+				"creation_date": 1748800100,
+			},
+			{
+				"timeline_type": "accepted_answer", "post_id": 7,
+				"user": map[string]string{"display_name": "bob"}, "creation_date": 1748800200,
+			},
+			{"timeline_type": "unaccepted_answer", "post_id": 7, "creation_date": 1748800300},
+			{"timeline_type": "accepted_answer", "post_id": 7, "creation_date": 1748800400},
+			{"timeline_type": "comment", "post_id": 42, "creation_date": 1748800500},
+		}},
+	})
+
+	updates, score, err := client.RetrieveScoredUpdates(context.Background(),
+		"https://stackoverflow.com/questions/42", time.Unix(1748790000, 0), nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), *score)
+
+	type event struct {
+		kind  models.Kind
+		title string
+		url   string
+	}
+
+	events := make(map[string]event, len(updates))
+	for _, update := range updates {
+		events[update.ID] = event{update.Kind, update.Title, update.URL}
+	}
+
+	// Edits of answers are not the question being edited, and acceptance
+	// is keyed by its date because an answer can be accepted more than once.
+	require.Equal(t, map[string]event{
+		"edit:AB12": {models.KindEdit, `Why "nil"? edited`, "https://stackoverflow.com/posts/42/revisions"},
+		"accepted:7:1748800200": {
+			models.KindAccepted, `answer to Why "nil"? accepted`, "https://stackoverflow.com/a/7",
+		},
+		"unaccepted:7:1748800300": {
+			models.KindUnaccepted, `answer to Why "nil"? no longer accepted`, "https://stackoverflow.com/a/7",
+		},
+		"accepted:7:1748800400": {
+			models.KindAccepted, `answer to Why "nil"? accepted`, "https://stackoverflow.com/a/7",
+		},
+	}, events)
+}
+
+func TestQuestionUpdates(t *testing.T) {
+	score := func(n int64) *int64 { return &n }
+
+	tests := map[string]struct {
+		previous *int64
+		score    int64
+		bounty   bool
+		expected map[string]string
+	}{
+		"first poll records the score": {
+			previous: nil,
+			score:    120,
+			expected: map[string]string{},
+		},
+		"below the first threshold": {
+			previous: score(3),
+			score:    9,
+			expected: map[string]string{},
+		},
+		"crossing one threshold": {
+			previous: score(9),
+			score:    10,
+			expected: map[string]string{"score:10": "Question reached a score of 10"},
+		},
+		"crossing several thresholds reports the highest": {
+			previous: score(9),
+			score:    60,
+			expected: map[string]string{"score:50": "Question reached a score of 50"},
+		},
+		"already above the threshold": {
+			previous: score(12),
+			score:    20,
+			expected: map[string]string{},
+		},
+		"dropping below": {
+			previous: score(30),
+			score:    20,
+			expected: map[string]string{},
+		},
+		"open bounty": {
+			previous: score(3),
+			score:    3,
+			bounty:   true,
+			expected: map[string]string{"bounty:1749000000": "+50 bounty on Question"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			question := map[string]any{"question_id": 42, "title": "Question", "score": test.score}
+			if test.bounty {
+				question["bounty_amount"] = 50
+				question["bounty_closes_date"] = 1749000000
+			}
+
+			client, _ := stub(t, map[string]any{
+				"/questions/42": map[string]any{"items": []map[string]any{question}},
+			})
+
+			before := time.Now().Truncate(time.Second)
+
+			updates, score, err := client.RetrieveScoredUpdates(context.Background(),
+				"https://stackoverflow.com/questions/42", time.Now().Add(-time.Hour), test.previous)
+			require.NoError(t, err)
+			require.Equal(t, test.score, *score)
+
+			titles := make(map[string]string, len(updates))
+			for _, update := range updates {
+				titles[update.ID] = update.Title
+
+				// Neither a bounty start nor a score change has a date in the API.
+				require.False(t, update.CreatedAt.Before(before), update.ID)
+			}
+
+			require.Equal(t, test.expected, titles)
+		})
+	}
+}
+
+func TestRetrieveScoredUpdatesTagFeed(t *testing.T) {
+	client, _ := stub(t, map[string]any{})
+
+	previous := int64(5)
+
+	_, score, err := client.RetrieveScoredUpdates(context.Background(),
+		"https://stackoverflow.com/questions/tagged/go", time.Now().Add(-2*time.Hour), &previous)
+	require.NoError(t, err)
+	require.Equal(t, &previous, score)
+}
@@ -0,0 +1,90 @@
+package stackoverflow
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+)
+
+type StackOverflowQuestion struct {
+	QuestionID  int64    `json:"question_id"`
+	Title       string   `json:"title"`
+	Link        string   `json:"link"`
+	Body        string   `json:"body"`
+	Tags        []string `json:"tags"`
+	Score       int64    `json:"score"`
+	AnswerCount int64    `json:"answer_count"`
+	Owner       struct {
+		Username string `json:"display_name"`
+	} `json:"owner"`
+	CreatedAt        int64 `json:"creation_date"`
+	BountyAmount     int64 `json:"bounty_amount"`
+	BountyClosesDate int64 `json:"bounty_closes_date"`
+}
+
+type StackOverflowQuestions struct {
+	Items []StackOverflowQuestion `json:"items"`
+}
+
+// questionSettle is how old a question of a tag feed gets before it is reported.
+const questionSettle = time.Hour
+
+// retrieveQuestions lists the questions that carry all the tags, asked since the given
+// time and at least questionSettle ago. Their tags, score and answer state become labels
+// the score and unanswered filters read, so those judge a question as it stands an hour
+// after it was asked rather than at the moment it was posted.
+func (s *StackOverflowClient) retrieveQuestions(
+	ctx context.Context,
+	t target,
+	since time.Time,
+	now time.Time,
+) ([]models.Update, error) {
+	settled := now.Add(-questionSettle)
+	if !settled.After(since) {
+		return []models.Update{}, nil
+	}
+
+	var questions StackOverflowQuestions
+	if _, err := s.client.R().
+		SetContext(ctx).
+		SetQueryParams(map[string]string{
+			"order":    "desc",
+			"sort":     "creation",
+			"site":     t.site,
+			"filter":   "withbody",
+			"tagged":   strings.Join(t.tags, ";"),
+			"fromdate": strconv.FormatInt(since.Unix(), 10),
+			"todate":   strconv.FormatInt(settled.Unix(), 10),
+		}).
+		SetResult(&questions).
+		Get("questions"); err != nil {
+		return nil, fmt.Errorf("stackoverflow client: failed to fetch questions updates")
+	}
+
+	updates := make([]models.Update, 0, len(questions.Items))
+
+	for _, question := range questions.Items {
+		labels := append(question.Tags, models.LabelScore+strconv.FormatInt(question.Score, 10))
+		if question.AnswerCount == 0 {
+			labels = append(labels, models.LabelUnanswered)
+		}
+
+		updates = append(updates, models.NewUpdate(
+			"question:"+strconv.FormatInt(question.QuestionID, 10),
+			models.KindQuestion,
+			html.UnescapeString(question.Title),
+			"https://"+t.site+"/questions/"+strconv.FormatInt(question.QuestionID, 10),
+			time.Unix(question.CreatedAt, 0).UTC(),
+			question.Owner.Username,
+			question.Body,
+			labels...,
+		))
+	}
+
+	return updates, nil
+}
@@ -0,0 +1,146 @@
+package stackoverflow_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/filter"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/sources/stackoverflow"
+	"github.com/stretchr/testify/require"
+)
+
+// stub serves the StackExchange API paths with canned responses
+// and records the query of every request by path.
+func stub(t *testing.T, responses map[string]any) (*stackoverflow.StackOverflowClient, map[string]url.Values) {
+	t.Helper()
+
+	var mu sync.Mutex
+
+	queries := make(map[string]url.Values)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries[r.URL.Path] = r.URL.Query()
+		mu.Unlock()
+
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			response = map[string]any{"items": []any{}}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{}
+	cfg.StackExchange.BaseURL = server.URL
+
+	client := stackoverflow.NewStackOverflowClient(cfg)
+	t.Cleanup(func() { _ = client.Close() })
+
+	return client, queries
+}
+
+func TestRetrieveQuestions(t *testing.T) {
+	asked := time.Now().Add(-2 * time.Hour).Unix()
+
+	question := func(id, score, answers int64, title string) map[string]any {
+		return map[string]any{
+			"question_id": id, "title": title, "tags": []string{"go", "pgx"},
+			"score": score, "answer_count": answers, "body": "<p>body</p>",
+			"owner": map[string]string{"display_name": "alice"}, "creation_date": asked,
+		}
+	}
+
+	client, queries := stub(t, map[string]any{
+		"/questions": map[string]any{"items": []map[string]any{
+			question(1, 5, 2, "Pool exhausted &amp; hangs"),
+			question(2, 0, 0, "Scan into struct"),
+			question(3, -1, 0, "Off-topic"),
+		}},
+	})
+
+	since := time.Now().Add(-3 * time.Hour)
+
+	updates, err := client.RetrieveUpdates(context.Background(),
+		"https://stackoverflow.com/questions/tagged/pgx+go", since)
+	require.NoError(t, err)
+
+	query := queries["/questions"]
+	require.Equal(t, "go;pgx", query.Get("tagged"))
+	require.Equal(t, "stackoverflow.com", query.Get("site"))
+	require.Equal(t, strconv.FormatInt(since.Unix(), 10), query.Get("fromdate"))
+
+	// Questions are reported an hour after they are asked, once their score and answers settle.
+	todate, err := strconv.ParseInt(query.Get("todate"), 10, 64)
+	require.NoError(t, err)
+	require.InDelta(t, time.Now().Add(-time.Hour).Unix(), todate, 5)
+
+	require.Len(t, updates, 3)
+	require.Equal(t, "question:1", updates[0].ID)
+	require.Equal(t, models.KindQuestion, updates[0].Kind)
+	require.Equal(t, "Pool exhausted & hangs", updates[0].Title)
+	require.Equal(t, "https://stackoverflow.com/questions/1", updates[0].URL)
+	require.Equal(t, []string{"go", "pgx", "score:5"}, updates[0].Labels)
+	require.Equal(t, []string{"go", "pgx", "score:0", models.LabelUnanswered}, updates[1].Labels)
+
+	tests := map[string]struct {
+		filters  []string
+		expected []string
+	}{
+		"score at least two": {
+			filters:  []string{"score:>=2"},
+			expected: []string{"question:1"},
+		},
+		"not downvoted": {
+			filters:  []string{"score:>=0"},
+			expected: []string{"question:1", "question:2"},
+		},
+		"unanswered": {
+			filters:  []string{"unanswered:true"},
+			expected: []string{"question:2", "question:3"},
+		},
+		"answered": {
+			filters:  []string{"unanswered:false"},
+			expected: []string{"question:1"},
+		},
+		"unanswered and not downvoted": {
+			filters:  []string{"unanswered:true", "-score:<0"},
+			expected: []string{"question:2"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f, err := filter.Parse(test.filters)
+			require.NoError(t, err)
+
+			ids := make([]string, 0, len(updates))
+			for _, update := range f.Apply(updates) {
+				ids = append(ids, update.ID)
+			}
+
+			require.Equal(t, test.expected, ids)
+		})
+	}
+}
+
+func TestRetrieveQuestionsNotSettled(t *testing.T) {
+	client, queries := stub(t, map[string]any{})
+
+	updates, err := client.RetrieveUpdates(context.Background(),
+		"https://stackoverflow.com/questions/tagged/go", time.Now().Add(-30*time.Minute))
+	require.NoError(t, err)
+	require.Empty(t, updates)
+	require.Empty(t, queries, "nothing asked since the cursor has settled yet")
+}
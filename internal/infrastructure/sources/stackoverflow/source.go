@@ -0,0 +1,159 @@
+package stackoverflow
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/es-debug/backend-academy-2024-go-template/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/sources"
+)
+
+// hosts are the StackExchange sites outside stackexchange.com. Their
+// subdomains, such as ru.stackoverflow.com or meta.serverfault.com, are sites too.
+var hosts = []string{
+	"stackoverflow.com",
+	"serverfault.com",
+	"superuser.com",
+	"askubuntu.com",
+	"stackapps.com",
+	"mathoverflow.net",
+}
+
+const network = "stackexchange.com"
+
+func init() {
+	sources.Register(Source{})
+}
+
+type Source struct{}
+
+func (Source) Name() string { return config.StackOverflow }
+
+func (Source) Match(u *url.URL) bool {
+	return site(u) != ""
+}
+
+// site returns the domain of the StackExchange site a link belongs to, which the API
+// accepts as its site parameter, or an empty string for any other host.
+func site(u *url.URL) string {
+	h := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	h = strings.TrimPrefix(strings.TrimPrefix(h, "www."), "m.")
+
+	for _, known := range hosts {
+		if h == known || strings.HasSuffix(h, "."+known) {
+			return h
+		}
+	}
+
+	// The network domain itself and its API and chat hosts are not sites.
+	sub, ok := strings.CutSuffix(h, "."+network)
+	if !ok || sub == "api" || sub == "chat" || strings.HasPrefix(sub, "chat.") {
+		return ""
+	}
+
+	return h
+}
+
+func (Source) Validate(u *url.URL) error {
+	_, err := locate(u)
+	return err
+}
+
+// Canonical drops the title slug and answer anchors, leaving only the question id,
+// and lists the tags of a tag feed in lowercase and sorted.
+func (Source) Canonical(u *url.URL) string {
+	t, _ := locate(u)
+
+	canonical := url.URL{
+		Scheme: config.SchemeSecure,
+		Host:   t.site,
+		Path:   "/questions/" + t.question,
+	}
+
+	if len(t.tags) > 0 {
+		escaped := make([]string, 0, len(t.tags))
+		for _, tag := range t.tags {
+			escaped = append(escaped, escapeTag(tag))
+		}
+
+		canonical.Path = "/questions/tagged/" + strings.Join(t.tags, "+")
+		canonical.RawPath = "/questions/tagged/" + strings.Join(escaped, "+")
+	}
+
+	return canonical.String()
+}
+
+func (Source) NewClient(cfg *config.Config) sources.Client {
+	return NewStackOverflowClient(cfg)
+}
+
+// target is either a single question or a feed of the questions
+// carrying all of the tags, on one of the StackExchange sites.
+type target struct {
+	site     string
+	question string
+	tags     []string
+}
+
+// locate reads questions/<id>, q/<id> and questions/tagged/<tag>+<tag> links.
+// A plus inside a tag name, as in c++, arrives escaped as %2B.
+func locate(u *url.URL) (target, error) {
+	host := site(u)
+	if host == "" {
+		return target{}, sources.ErrInvalidPath
+	}
+
+	parts := strings.FieldsFunc(u.EscapedPath(), func(r rune) bool { return r == '/' })
+	if len(parts) < 2 {
+		return target{}, sources.ErrInvalidPath
+	}
+
+	switch strings.ToLower(parts[0]) {
+	case "questions", "q":
+	default:
+		return target{}, sources.ErrInvalidPath
+	}
+
+	if strings.ToLower(parts[0]) == "questions" && strings.ToLower(parts[1]) == "tagged" {
+		if len(parts) != 3 {
+			return target{}, sources.ErrInvalidPath
+		}
+
+		tags, err := parseTags(parts[2])
+		if err != nil {
+			return target{}, err
+		}
+
+		return target{site: host, tags: tags}, nil
+	}
+
+	for _, r := range parts[1] {
+		if r < '0' || r > '9' {
+			return target{}, sources.ErrInvalidPath
+		}
+	}
+
+	return target{site: host, question: parts[1]}, nil
+}
+
+func parseTags(raw string) ([]string, error) {
+	tags := make([]string, 0, strings.Count(raw, "+")+1)
+
+	for _, escaped := range strings.Split(raw, "+") {
+		tag, err := url.PathUnescape(escaped)
+		if err != nil || tag == "" || strings.ContainsAny(tag, " ;") {
+			return nil, sources.ErrInvalidPath
+		}
+
+		tags = append(tags, strings.ToLower(tag))
+	}
+
+	slices.Sort(tags)
+
+	return slices.Compact(tags), nil
+}
+
+func escapeTag(tag string) string {
+	return strings.ReplaceAll(url.PathEscape(tag), "+", "%2B")
+}
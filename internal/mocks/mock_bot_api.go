@@ -37,6 +37,52 @@ func (_m *MockServerInterface) EXPECT() *MockServerInterface_Expecter {
 	return &MockServerInterface_Expecter{mock: &_m.Mock}
 }
 
+// PostDigests provides a mock function for the type MockServerInterface
+func (_mock *MockServerInterface) PostDigests(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockServerInterface_PostDigests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PostDigests'
+type MockServerInterface_PostDigests_Call struct {
+	*mock.Call
+}
+
+// PostDigests is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockServerInterface_Expecter) PostDigests(w interface{}, r interface{}) *MockServerInterface_PostDigests_Call {
+	return &MockServerInterface_PostDigests_Call{Call: _e.mock.On("PostDigests", w, r)}
+}
+
+func (_c *MockServerInterface_PostDigests_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockServerInterface_PostDigests_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockServerInterface_PostDigests_Call) Return() *MockServerInterface_PostDigests_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockServerInterface_PostDigests_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockServerInterface_PostDigests_Call {
+	_c.Run(run)
+	return _c
+}
+
 // PostUpdates provides a mock function for the type MockServerInterface
 func (_mock *MockServerInterface) PostUpdates(w http.ResponseWriter, r *http.Request) {
 	_mock.Called(w, r)
@@ -4,9 +4,11 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
-	models "github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
 	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
 )
 
 // MockExternalClient is an autogenerated mock type for the ExternalClient type
@@ -22,61 +24,72 @@ func (_m *MockExternalClient) EXPECT() *MockExternalClient_Expecter {
 	return &MockExternalClient_Expecter{mock: &_m.Mock}
 }
 
-// RetrieveUpdates provides a mock function with given fields: ctx, link
-func (_m *MockExternalClient) RetrieveUpdates(ctx context.Context, link string) ([]models.Update, error) {
-	ret := _m.Called(ctx, link)
+// RetrieveScoredUpdates provides a mock function with given fields: ctx, link, since, score
+func (_m *MockExternalClient) RetrieveScoredUpdates(ctx context.Context, link string, since time.Time, score *int64) ([]models.Update, *int64, error) {
+	ret := _m.Called(ctx, link, since, score)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RetrieveUpdates")
+		panic("no return value specified for RetrieveScoredUpdates")
 	}
 
 	var r0 []models.Update
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.Update, error)); ok {
-		return rf(ctx, link)
+	var r1 *int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, *int64) ([]models.Update, *int64, error)); ok {
+		return rf(ctx, link, since, score)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) []models.Update); ok {
-		r0 = rf(ctx, link)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, *int64) []models.Update); ok {
+		r0 = rf(ctx, link, since, score)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.Update)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, link)
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, *int64) *int64); ok {
+		r1 = rf(ctx, link, since, score)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*int64)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, time.Time, *int64) error); ok {
+		r2 = rf(ctx, link, since, score)
 	} else {
-		r1 = ret.Error(1)
+		r2 = ret.Error(2)
 	}
 
-	return r0, r1
+	return r0, r1, r2
 }
 
-// MockExternalClient_RetrieveUpdates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveUpdates'
-type MockExternalClient_RetrieveUpdates_Call struct {
+// MockExternalClient_RetrieveScoredUpdates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveScoredUpdates'
+type MockExternalClient_RetrieveScoredUpdates_Call struct {
 	*mock.Call
 }
 
-// RetrieveUpdates is a helper method to define mock.On call
+// RetrieveScoredUpdates is a helper method to define mock.On call
 //   - ctx context.Context
 //   - link string
-func (_e *MockExternalClient_Expecter) RetrieveUpdates(ctx interface{}, link interface{}) *MockExternalClient_RetrieveUpdates_Call {
-	return &MockExternalClient_RetrieveUpdates_Call{Call: _e.mock.On("RetrieveUpdates", ctx, link)}
+//   - since time.Time
+//   - score *int64
+func (_e *MockExternalClient_Expecter) RetrieveScoredUpdates(ctx interface{}, link interface{}, since interface{}, score interface{}) *MockExternalClient_RetrieveScoredUpdates_Call {
+	return &MockExternalClient_RetrieveScoredUpdates_Call{Call: _e.mock.On("RetrieveScoredUpdates", ctx, link, since, score)}
 }
 
-func (_c *MockExternalClient_RetrieveUpdates_Call) Run(run func(ctx context.Context, link string)) *MockExternalClient_RetrieveUpdates_Call {
+func (_c *MockExternalClient_RetrieveScoredUpdates_Call) Run(run func(ctx context.Context, link string, since time.Time, score *int64)) *MockExternalClient_RetrieveScoredUpdates_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time), args[3].(*int64))
 	})
 	return _c
 }
 
-func (_c *MockExternalClient_RetrieveUpdates_Call) Return(_a0 []models.Update, _a1 error) *MockExternalClient_RetrieveUpdates_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockExternalClient_RetrieveScoredUpdates_Call) Return(_a0 []models.Update, _a1 *int64, _a2 error) *MockExternalClient_RetrieveScoredUpdates_Call {
+	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
 
-func (_c *MockExternalClient_RetrieveUpdates_Call) RunAndReturn(run func(context.Context, string) ([]models.Update, error)) *MockExternalClient_RetrieveUpdates_Call {
+func (_c *MockExternalClient_RetrieveScoredUpdates_Call) RunAndReturn(run func(context.Context, string, time.Time, *int64) ([]models.Update, *int64, error)) *MockExternalClient_RetrieveScoredUpdates_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -121,3 +121,86 @@ func (_c *MockHTTPSender_PostUpdates_Call) RunAndReturn(run func(ctx context.Con
 	_c.Call.Return(run)
 	return _c
 }
+
+// PostDigests provides a mock function for the type MockHTTPSender
+func (_mock *MockHTTPSender) PostDigests(ctx context.Context, body botclient.PostDigestsJSONRequestBody, reqEditors ...botclient.RequestEditorFn) (*http.Response, error) {
+	var tmpRet mock.Arguments
+	if len(reqEditors) > 0 {
+		tmpRet = _mock.Called(ctx, body, reqEditors)
+	} else {
+		tmpRet = _mock.Called(ctx, body)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for PostDigests")
+	}
+
+	var r0 *http.Response
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, botclient.PostDigestsJSONRequestBody, ...botclient.RequestEditorFn) (*http.Response, error)); ok {
+		return returnFunc(ctx, body, reqEditors...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, botclient.PostDigestsJSONRequestBody, ...botclient.RequestEditorFn) *http.Response); ok {
+		r0 = returnFunc(ctx, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*http.Response)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, botclient.PostDigestsJSONRequestBody, ...botclient.RequestEditorFn) error); ok {
+		r1 = returnFunc(ctx, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockHTTPSender_PostDigests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PostDigests'
+type MockHTTPSender_PostDigests_Call struct {
+	*mock.Call
+}
+
+// PostDigests is a helper method to define mock.On call
+//   - ctx context.Context
+//   - body botclient.PostDigestsJSONRequestBody
+//   - reqEditors ...botclient.RequestEditorFn
+func (_e *MockHTTPSender_Expecter) PostDigests(ctx interface{}, body interface{}, reqEditors ...interface{}) *MockHTTPSender_PostDigests_Call {
+	return &MockHTTPSender_PostDigests_Call{Call: _e.mock.On("PostDigests",
+		append([]interface{}{ctx, body}, reqEditors...)...)}
+}
+
+func (_c *MockHTTPSender_PostDigests_Call) Run(run func(ctx context.Context, body botclient.PostDigestsJSONRequestBody, reqEditors ...botclient.RequestEditorFn)) *MockHTTPSender_PostDigests_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 botclient.PostDigestsJSONRequestBody
+		if args[1] != nil {
+			arg1 = args[1].(botclient.PostDigestsJSONRequestBody)
+		}
+		var arg2 []botclient.RequestEditorFn
+		var variadicArgs []botclient.RequestEditorFn
+		if len(args) > 2 {
+			variadicArgs = args[2].([]botclient.RequestEditorFn)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockHTTPSender_PostDigests_Call) Return(response *http.Response, err error) *MockHTTPSender_PostDigests_Call {
+	_c.Call.Return(response, err)
+	return _c
+}
+
+func (_c *MockHTTPSender_PostDigests_Call) RunAndReturn(run func(ctx context.Context, body botclient.PostDigestsJSONRequestBody, reqEditors ...botclient.RequestEditorFn) (*http.Response, error)) *MockHTTPSender_PostDigests_Call {
+	_c.Call.Return(run)
+	return _c
+}
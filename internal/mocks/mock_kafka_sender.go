@@ -7,6 +7,7 @@ package mocks
 import (
 	"context"
 
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -38,16 +39,16 @@ func (_m *MockKafkaSender) EXPECT() *MockKafkaSender_Expecter {
 }
 
 // Send provides a mock function for the type MockKafkaSender
-func (_mock *MockKafkaSender) Send(ctx context.Context, chatID int64, url string, description string) error {
-	ret := _mock.Called(ctx, chatID, url, description)
+func (_mock *MockKafkaSender) Send(ctx context.Context, chatID int64, url string, update models.Update) error {
+	ret := _mock.Called(ctx, chatID, url, update)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Send")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string, string) error); ok {
-		r0 = returnFunc(ctx, chatID, url, description)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string, models.Update) error); ok {
+		r0 = returnFunc(ctx, chatID, url, update)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -63,12 +64,12 @@ type MockKafkaSender_Send_Call struct {
 //   - ctx context.Context
 //   - chatID int64
 //   - url string
-//   - description string
-func (_e *MockKafkaSender_Expecter) Send(ctx interface{}, chatID interface{}, url interface{}, description interface{}) *MockKafkaSender_Send_Call {
-	return &MockKafkaSender_Send_Call{Call: _e.mock.On("Send", ctx, chatID, url, description)}
+//   - update models.Update
+func (_e *MockKafkaSender_Expecter) Send(ctx interface{}, chatID interface{}, url interface{}, update interface{}) *MockKafkaSender_Send_Call {
+	return &MockKafkaSender_Send_Call{Call: _e.mock.On("Send", ctx, chatID, url, update)}
 }
 
-func (_c *MockKafkaSender_Send_Call) Run(run func(ctx context.Context, chatID int64, url string, description string)) *MockKafkaSender_Send_Call {
+func (_c *MockKafkaSender_Send_Call) Run(run func(ctx context.Context, chatID int64, url string, update models.Update)) *MockKafkaSender_Send_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -82,9 +83,9 @@ func (_c *MockKafkaSender_Send_Call) Run(run func(ctx context.Context, chatID in
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
-		var arg3 string
+		var arg3 models.Update
 		if args[3] != nil {
-			arg3 = args[3].(string)
+			arg3 = args[3].(models.Update)
 		}
 		run(
 			arg0,
@@ -101,7 +102,64 @@ func (_c *MockKafkaSender_Send_Call) Return(err error) *MockKafkaSender_Send_Cal
 	return _c
 }
 
-func (_c *MockKafkaSender_Send_Call) RunAndReturn(run func(ctx context.Context, chatID int64, url string, description string) error) *MockKafkaSender_Send_Call {
+func (_c *MockKafkaSender_Send_Call) RunAndReturn(run func(ctx context.Context, chatID int64, url string, update models.Update) error) *MockKafkaSender_Send_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendDigest provides a mock function for the type MockKafkaSender
+func (_mock *MockKafkaSender) SendDigest(ctx context.Context, digest models.ChatDigest) error {
+	ret := _mock.Called(ctx, digest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendDigest")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.ChatDigest) error); ok {
+		r0 = returnFunc(ctx, digest)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockKafkaSender_SendDigest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendDigest'
+type MockKafkaSender_SendDigest_Call struct {
+	*mock.Call
+}
+
+// SendDigest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - digest models.ChatDigest
+func (_e *MockKafkaSender_Expecter) SendDigest(ctx interface{}, digest interface{}) *MockKafkaSender_SendDigest_Call {
+	return &MockKafkaSender_SendDigest_Call{Call: _e.mock.On("SendDigest", ctx, digest)}
+}
+
+func (_c *MockKafkaSender_SendDigest_Call) Run(run func(ctx context.Context, digest models.ChatDigest)) *MockKafkaSender_SendDigest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.ChatDigest
+		if args[1] != nil {
+			arg1 = args[1].(models.ChatDigest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockKafkaSender_SendDigest_Call) Return(err error) *MockKafkaSender_SendDigest_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockKafkaSender_SendDigest_Call) RunAndReturn(run func(ctx context.Context, digest models.ChatDigest) error) *MockKafkaSender_SendDigest_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -4,10 +4,12 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	mock "github.com/stretchr/testify/mock"
 
 	scrapperapi "github.com/es-debug/backend-academy-2024-go-template/internal/api/openapi/v1/servers/scrapper"
+	models "github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
 )
 
 // MockNotifierStorage is an autogenerated mock type for the Storage type
@@ -23,24 +25,83 @@ func (_m *MockNotifierStorage) EXPECT() *MockNotifierStorage_Expecter {
 	return &MockNotifierStorage_Expecter{mock: &_m.Mock}
 }
 
-// GetChatIDs provides a mock function with given fields: ctx
-func (_m *MockNotifierStorage) GetChatIDs(ctx context.Context) ([]int64, error) {
+// ClaimDigest provides a mock function with given fields: ctx, chatID, prev, at
+func (_m *MockNotifierStorage) ClaimDigest(ctx context.Context, chatID int64, prev time.Time, at time.Time) (bool, error) {
+	ret := _m.Called(ctx, chatID, prev, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDigest")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, time.Time) (bool, error)); ok {
+		return rf(ctx, chatID, prev, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, time.Time) bool); ok {
+		r0 = rf(ctx, chatID, prev, at)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, chatID, prev, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockNotifierStorage_ClaimDigest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimDigest'
+type MockNotifierStorage_ClaimDigest_Call struct {
+	*mock.Call
+}
+
+// ClaimDigest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+//   - prev time.Time
+//   - at time.Time
+func (_e *MockNotifierStorage_Expecter) ClaimDigest(ctx interface{}, chatID interface{}, prev interface{}, at interface{}) *MockNotifierStorage_ClaimDigest_Call {
+	return &MockNotifierStorage_ClaimDigest_Call{Call: _e.mock.On("ClaimDigest", ctx, chatID, prev, at)}
+}
+
+func (_c *MockNotifierStorage_ClaimDigest_Call) Run(run func(ctx context.Context, chatID int64, prev time.Time, at time.Time)) *MockNotifierStorage_ClaimDigest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(time.Time), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockNotifierStorage_ClaimDigest_Call) Return(_a0 bool, _a1 error) *MockNotifierStorage_ClaimDigest_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockNotifierStorage_ClaimDigest_Call) RunAndReturn(run func(context.Context, int64, time.Time, time.Time) (bool, error)) *MockNotifierStorage_ClaimDigest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChatDigests provides a mock function with given fields: ctx
+func (_m *MockNotifierStorage) GetChatDigests(ctx context.Context) ([]models.Digest, error) {
 	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetChatIDs")
+		panic("no return value specified for GetChatDigests")
 	}
 
-	var r0 []int64
+	var r0 []models.Digest
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]int64, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.Digest, error)); ok {
 		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []int64); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) []models.Digest); ok {
 		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]int64)
+			r0 = ret.Get(0).([]models.Digest)
 		}
 	}
 
@@ -53,40 +114,40 @@ func (_m *MockNotifierStorage) GetChatIDs(ctx context.Context) ([]int64, error)
 	return r0, r1
 }
 
-// MockNotifierStorage_GetChatIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChatIDs'
-type MockNotifierStorage_GetChatIDs_Call struct {
+// MockNotifierStorage_GetChatDigests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChatDigests'
+type MockNotifierStorage_GetChatDigests_Call struct {
 	*mock.Call
 }
 
-// GetChatIDs is a helper method to define mock.On call
+// GetChatDigests is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockNotifierStorage_Expecter) GetChatIDs(ctx interface{}) *MockNotifierStorage_GetChatIDs_Call {
-	return &MockNotifierStorage_GetChatIDs_Call{Call: _e.mock.On("GetChatIDs", ctx)}
+func (_e *MockNotifierStorage_Expecter) GetChatDigests(ctx interface{}) *MockNotifierStorage_GetChatDigests_Call {
+	return &MockNotifierStorage_GetChatDigests_Call{Call: _e.mock.On("GetChatDigests", ctx)}
 }
 
-func (_c *MockNotifierStorage_GetChatIDs_Call) Run(run func(ctx context.Context)) *MockNotifierStorage_GetChatIDs_Call {
+func (_c *MockNotifierStorage_GetChatDigests_Call) Run(run func(ctx context.Context)) *MockNotifierStorage_GetChatDigests_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockNotifierStorage_GetChatIDs_Call) Return(_a0 []int64, _a1 error) *MockNotifierStorage_GetChatIDs_Call {
+func (_c *MockNotifierStorage_GetChatDigests_Call) Return(_a0 []models.Digest, _a1 error) *MockNotifierStorage_GetChatDigests_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockNotifierStorage_GetChatIDs_Call) RunAndReturn(run func(context.Context) ([]int64, error)) *MockNotifierStorage_GetChatIDs_Call {
+func (_c *MockNotifierStorage_GetChatDigests_Call) RunAndReturn(run func(context.Context) ([]models.Digest, error)) *MockNotifierStorage_GetChatDigests_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetLinksWithChatActive provides a mock function with given fields: ctx, chatID
-func (_m *MockNotifierStorage) GetLinksWithChatActive(ctx context.Context, chatID int64) ([]scrapperapi.LinkResponse, error) {
+// GetLinksWithChatPending provides a mock function with given fields: ctx, chatID
+func (_m *MockNotifierStorage) GetLinksWithChatPending(ctx context.Context, chatID int64) ([]scrapperapi.LinkResponse, error) {
 	ret := _m.Called(ctx, chatID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLinksWithChatActive")
+		panic("no return value specified for GetLinksWithChatPending")
 	}
 
 	var r0 []scrapperapi.LinkResponse
@@ -111,46 +172,164 @@ func (_m *MockNotifierStorage) GetLinksWithChatActive(ctx context.Context, chatI
 	return r0, r1
 }
 
-// MockNotifierStorage_GetLinksWithChatActive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLinksWithChatActive'
-type MockNotifierStorage_GetLinksWithChatActive_Call struct {
+// MockNotifierStorage_GetLinksWithChatPending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLinksWithChatPending'
+type MockNotifierStorage_GetLinksWithChatPending_Call struct {
 	*mock.Call
 }
 
-// GetLinksWithChatActive is a helper method to define mock.On call
+// GetLinksWithChatPending is a helper method to define mock.On call
 //   - ctx context.Context
 //   - chatID int64
-func (_e *MockNotifierStorage_Expecter) GetLinksWithChatActive(ctx interface{}, chatID interface{}) *MockNotifierStorage_GetLinksWithChatActive_Call {
-	return &MockNotifierStorage_GetLinksWithChatActive_Call{Call: _e.mock.On("GetLinksWithChatActive", ctx, chatID)}
+func (_e *MockNotifierStorage_Expecter) GetLinksWithChatPending(ctx interface{}, chatID interface{}) *MockNotifierStorage_GetLinksWithChatPending_Call {
+	return &MockNotifierStorage_GetLinksWithChatPending_Call{Call: _e.mock.On("GetLinksWithChatPending", ctx, chatID)}
 }
 
-func (_c *MockNotifierStorage_GetLinksWithChatActive_Call) Run(run func(ctx context.Context, chatID int64)) *MockNotifierStorage_GetLinksWithChatActive_Call {
+func (_c *MockNotifierStorage_GetLinksWithChatPending_Call) Run(run func(ctx context.Context, chatID int64)) *MockNotifierStorage_GetLinksWithChatPending_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(int64))
 	})
 	return _c
 }
 
-func (_c *MockNotifierStorage_GetLinksWithChatActive_Call) Return(_a0 []scrapperapi.LinkResponse, _a1 error) *MockNotifierStorage_GetLinksWithChatActive_Call {
+func (_c *MockNotifierStorage_GetLinksWithChatPending_Call) Return(_a0 []scrapperapi.LinkResponse, _a1 error) *MockNotifierStorage_GetLinksWithChatPending_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockNotifierStorage_GetLinksWithChatPending_Call) RunAndReturn(run func(context.Context, int64) ([]scrapperapi.LinkResponse, error)) *MockNotifierStorage_GetLinksWithChatPending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingUpdates provides a mock function with given fields: ctx, linkID, cursor
+func (_m *MockNotifierStorage) GetPendingUpdates(ctx context.Context, linkID int64, cursor models.Cursor) ([]models.Update, error) {
+	ret := _m.Called(ctx, linkID, cursor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingUpdates")
+	}
+
+	var r0 []models.Update
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.Cursor) ([]models.Update, error)); ok {
+		return rf(ctx, linkID, cursor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.Cursor) []models.Update); ok {
+		r0 = rf(ctx, linkID, cursor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Update)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, models.Cursor) error); ok {
+		r1 = rf(ctx, linkID, cursor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockNotifierStorage_GetPendingUpdates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingUpdates'
+type MockNotifierStorage_GetPendingUpdates_Call struct {
+	*mock.Call
+}
+
+// GetPendingUpdates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - linkID int64
+//   - cursor models.Cursor
+func (_e *MockNotifierStorage_Expecter) GetPendingUpdates(ctx interface{}, linkID interface{}, cursor interface{}) *MockNotifierStorage_GetPendingUpdates_Call {
+	return &MockNotifierStorage_GetPendingUpdates_Call{Call: _e.mock.On("GetPendingUpdates", ctx, linkID, cursor)}
+}
+
+func (_c *MockNotifierStorage_GetPendingUpdates_Call) Run(run func(ctx context.Context, linkID int64, cursor models.Cursor)) *MockNotifierStorage_GetPendingUpdates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(models.Cursor))
+	})
+	return _c
+}
+
+func (_c *MockNotifierStorage_GetPendingUpdates_Call) Return(_a0 []models.Update, _a1 error) *MockNotifierStorage_GetPendingUpdates_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockNotifierStorage_GetPendingUpdates_Call) RunAndReturn(run func(context.Context, int64, models.Cursor) ([]models.Update, error)) *MockNotifierStorage_GetPendingUpdates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSubscriptionCursor provides a mock function with given fields: ctx, chatID, linkID
+func (_m *MockNotifierStorage) GetSubscriptionCursor(ctx context.Context, chatID int64, linkID int64) (models.Cursor, error) {
+	ret := _m.Called(ctx, chatID, linkID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubscriptionCursor")
+	}
+
+	var r0 models.Cursor
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (models.Cursor, error)); ok {
+		return rf(ctx, chatID, linkID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) models.Cursor); ok {
+		r0 = rf(ctx, chatID, linkID)
+	} else {
+		r0 = ret.Get(0).(models.Cursor)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, chatID, linkID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockNotifierStorage_GetSubscriptionCursor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSubscriptionCursor'
+type MockNotifierStorage_GetSubscriptionCursor_Call struct {
+	*mock.Call
+}
+
+// GetSubscriptionCursor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+//   - linkID int64
+func (_e *MockNotifierStorage_Expecter) GetSubscriptionCursor(ctx interface{}, chatID interface{}, linkID interface{}) *MockNotifierStorage_GetSubscriptionCursor_Call {
+	return &MockNotifierStorage_GetSubscriptionCursor_Call{Call: _e.mock.On("GetSubscriptionCursor", ctx, chatID, linkID)}
+}
+
+func (_c *MockNotifierStorage_GetSubscriptionCursor_Call) Run(run func(ctx context.Context, chatID int64, linkID int64)) *MockNotifierStorage_GetSubscriptionCursor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockNotifierStorage_GetSubscriptionCursor_Call) Return(_a0 models.Cursor, _a1 error) *MockNotifierStorage_GetSubscriptionCursor_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockNotifierStorage_GetLinksWithChatActive_Call) RunAndReturn(run func(context.Context, int64) ([]scrapperapi.LinkResponse, error)) *MockNotifierStorage_GetLinksWithChatActive_Call {
+func (_c *MockNotifierStorage_GetSubscriptionCursor_Call) RunAndReturn(run func(context.Context, int64, int64) (models.Cursor, error)) *MockNotifierStorage_GetSubscriptionCursor_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateLinkActivity provides a mock function with given fields: ctx, linkID, status
-func (_m *MockNotifierStorage) UpdateLinkActivity(ctx context.Context, linkID int64, status bool) error {
-	ret := _m.Called(ctx, linkID, status)
+// UpdateSubscriptionCursor provides a mock function with given fields: ctx, chatID, linkID, cursor
+func (_m *MockNotifierStorage) UpdateSubscriptionCursor(ctx context.Context, chatID int64, linkID int64, cursor models.Cursor) error {
+	ret := _m.Called(ctx, chatID, linkID, cursor)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateLinkActivity")
+		panic("no return value specified for UpdateSubscriptionCursor")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64, bool) error); ok {
-		r0 = rf(ctx, linkID, status)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, models.Cursor) error); ok {
+		r0 = rf(ctx, chatID, linkID, cursor)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -158,32 +337,33 @@ func (_m *MockNotifierStorage) UpdateLinkActivity(ctx context.Context, linkID in
 	return r0
 }
 
-// MockNotifierStorage_UpdateLinkActivity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateLinkActivity'
-type MockNotifierStorage_UpdateLinkActivity_Call struct {
+// MockNotifierStorage_UpdateSubscriptionCursor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSubscriptionCursor'
+type MockNotifierStorage_UpdateSubscriptionCursor_Call struct {
 	*mock.Call
 }
 
-// UpdateLinkActivity is a helper method to define mock.On call
+// UpdateSubscriptionCursor is a helper method to define mock.On call
 //   - ctx context.Context
+//   - chatID int64
 //   - linkID int64
-//   - status bool
-func (_e *MockNotifierStorage_Expecter) UpdateLinkActivity(ctx interface{}, linkID interface{}, status interface{}) *MockNotifierStorage_UpdateLinkActivity_Call {
-	return &MockNotifierStorage_UpdateLinkActivity_Call{Call: _e.mock.On("UpdateLinkActivity", ctx, linkID, status)}
+//   - cursor models.Cursor
+func (_e *MockNotifierStorage_Expecter) UpdateSubscriptionCursor(ctx interface{}, chatID interface{}, linkID interface{}, cursor interface{}) *MockNotifierStorage_UpdateSubscriptionCursor_Call {
+	return &MockNotifierStorage_UpdateSubscriptionCursor_Call{Call: _e.mock.On("UpdateSubscriptionCursor", ctx, chatID, linkID, cursor)}
 }
 
-func (_c *MockNotifierStorage_UpdateLinkActivity_Call) Run(run func(ctx context.Context, linkID int64, status bool)) *MockNotifierStorage_UpdateLinkActivity_Call {
+func (_c *MockNotifierStorage_UpdateSubscriptionCursor_Call) Run(run func(ctx context.Context, chatID int64, linkID int64, cursor models.Cursor)) *MockNotifierStorage_UpdateSubscriptionCursor_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int64), args[2].(bool))
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(models.Cursor))
 	})
 	return _c
 }
 
-func (_c *MockNotifierStorage_UpdateLinkActivity_Call) Return(_a0 error) *MockNotifierStorage_UpdateLinkActivity_Call {
+func (_c *MockNotifierStorage_UpdateSubscriptionCursor_Call) Return(_a0 error) *MockNotifierStorage_UpdateSubscriptionCursor_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockNotifierStorage_UpdateLinkActivity_Call) RunAndReturn(run func(context.Context, int64, bool) error) *MockNotifierStorage_UpdateLinkActivity_Call {
+func (_c *MockNotifierStorage_UpdateSubscriptionCursor_Call) RunAndReturn(run func(context.Context, int64, int64, models.Cursor) error) *MockNotifierStorage_UpdateSubscriptionCursor_Call {
 	_c.Call.Return(run)
 	return _c
 }
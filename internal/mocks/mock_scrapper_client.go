@@ -321,6 +321,80 @@ func (_c *MockScrapperClient_GetTgChatId_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// GetTgChatIdTemplate provides a mock function with given fields: ctx, id, reqEditors
+func (_m *MockScrapperClient) GetTgChatIdTemplate(ctx context.Context, id int64, reqEditors ...scrapperclient.RequestEditorFn) (*http.Response, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, id)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTgChatIdTemplate")
+	}
+
+	var r0 *http.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, ...scrapperclient.RequestEditorFn) (*http.Response, error)); ok {
+		return rf(ctx, id, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, ...scrapperclient.RequestEditorFn) *http.Response); ok {
+		r0 = rf(ctx, id, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*http.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, ...scrapperclient.RequestEditorFn) error); ok {
+		r1 = rf(ctx, id, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockScrapperClient_GetTgChatIdTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTgChatIdTemplate'
+type MockScrapperClient_GetTgChatIdTemplate_Call struct {
+	*mock.Call
+}
+
+// GetTgChatIdTemplate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - reqEditors ...scrapperclient.RequestEditorFn
+func (_e *MockScrapperClient_Expecter) GetTgChatIdTemplate(ctx interface{}, id interface{}, reqEditors ...interface{}) *MockScrapperClient_GetTgChatIdTemplate_Call {
+	return &MockScrapperClient_GetTgChatIdTemplate_Call{Call: _e.mock.On("GetTgChatIdTemplate",
+		append([]interface{}{ctx, id}, reqEditors...)...)}
+}
+
+func (_c *MockScrapperClient_GetTgChatIdTemplate_Call) Run(run func(ctx context.Context, id int64, reqEditors ...scrapperclient.RequestEditorFn)) *MockScrapperClient_GetTgChatIdTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]scrapperclient.RequestEditorFn, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(scrapperclient.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(int64), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockScrapperClient_GetTgChatIdTemplate_Call) Return(_a0 *http.Response, _a1 error) *MockScrapperClient_GetTgChatIdTemplate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockScrapperClient_GetTgChatIdTemplate_Call) RunAndReturn(run func(context.Context, int64, ...scrapperclient.RequestEditorFn) (*http.Response, error)) *MockScrapperClient_GetTgChatIdTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // PostLinks provides a mock function with given fields: ctx, params, body, reqEditors
 func (_m *MockScrapperClient) PostLinks(ctx context.Context, params *scrapperclient.PostLinksParams, body scrapperclient.AddLinkRequest, reqEditors ...scrapperclient.RequestEditorFn) (*http.Response, error) {
 	_va := make([]interface{}, len(reqEditors))
@@ -470,6 +544,156 @@ func (_c *MockScrapperClient_PostTgChatId_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
+// PutTgChatIdDigest provides a mock function with given fields: ctx, id, body, reqEditors
+func (_m *MockScrapperClient) PutTgChatIdDigest(ctx context.Context, id int64, body scrapperclient.UpdateDigestRequest, reqEditors ...scrapperclient.RequestEditorFn) (*http.Response, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, id, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PutTgChatIdDigest")
+	}
+
+	var r0 *http.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, scrapperclient.UpdateDigestRequest, ...scrapperclient.RequestEditorFn) (*http.Response, error)); ok {
+		return rf(ctx, id, body, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, scrapperclient.UpdateDigestRequest, ...scrapperclient.RequestEditorFn) *http.Response); ok {
+		r0 = rf(ctx, id, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*http.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, scrapperclient.UpdateDigestRequest, ...scrapperclient.RequestEditorFn) error); ok {
+		r1 = rf(ctx, id, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockScrapperClient_PutTgChatIdDigest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutTgChatIdDigest'
+type MockScrapperClient_PutTgChatIdDigest_Call struct {
+	*mock.Call
+}
+
+// PutTgChatIdDigest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - body scrapperclient.UpdateDigestRequest
+//   - reqEditors ...scrapperclient.RequestEditorFn
+func (_e *MockScrapperClient_Expecter) PutTgChatIdDigest(ctx interface{}, id interface{}, body interface{}, reqEditors ...interface{}) *MockScrapperClient_PutTgChatIdDigest_Call {
+	return &MockScrapperClient_PutTgChatIdDigest_Call{Call: _e.mock.On("PutTgChatIdDigest",
+		append([]interface{}{ctx, id, body}, reqEditors...)...)}
+}
+
+func (_c *MockScrapperClient_PutTgChatIdDigest_Call) Run(run func(ctx context.Context, id int64, body scrapperclient.UpdateDigestRequest, reqEditors ...scrapperclient.RequestEditorFn)) *MockScrapperClient_PutTgChatIdDigest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]scrapperclient.RequestEditorFn, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(scrapperclient.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(int64), args[2].(scrapperclient.UpdateDigestRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockScrapperClient_PutTgChatIdDigest_Call) Return(_a0 *http.Response, _a1 error) *MockScrapperClient_PutTgChatIdDigest_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockScrapperClient_PutTgChatIdDigest_Call) RunAndReturn(run func(context.Context, int64, scrapperclient.UpdateDigestRequest, ...scrapperclient.RequestEditorFn) (*http.Response, error)) *MockScrapperClient_PutTgChatIdDigest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PutTgChatIdTemplate provides a mock function with given fields: ctx, id, body, reqEditors
+func (_m *MockScrapperClient) PutTgChatIdTemplate(ctx context.Context, id int64, body scrapperclient.UpdateTemplateRequest, reqEditors ...scrapperclient.RequestEditorFn) (*http.Response, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, id, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PutTgChatIdTemplate")
+	}
+
+	var r0 *http.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, scrapperclient.UpdateTemplateRequest, ...scrapperclient.RequestEditorFn) (*http.Response, error)); ok {
+		return rf(ctx, id, body, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, scrapperclient.UpdateTemplateRequest, ...scrapperclient.RequestEditorFn) *http.Response); ok {
+		r0 = rf(ctx, id, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*http.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, scrapperclient.UpdateTemplateRequest, ...scrapperclient.RequestEditorFn) error); ok {
+		r1 = rf(ctx, id, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockScrapperClient_PutTgChatIdTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutTgChatIdTemplate'
+type MockScrapperClient_PutTgChatIdTemplate_Call struct {
+	*mock.Call
+}
+
+// PutTgChatIdTemplate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - body scrapperclient.UpdateTemplateRequest
+//   - reqEditors ...scrapperclient.RequestEditorFn
+func (_e *MockScrapperClient_Expecter) PutTgChatIdTemplate(ctx interface{}, id interface{}, body interface{}, reqEditors ...interface{}) *MockScrapperClient_PutTgChatIdTemplate_Call {
+	return &MockScrapperClient_PutTgChatIdTemplate_Call{Call: _e.mock.On("PutTgChatIdTemplate",
+		append([]interface{}{ctx, id, body}, reqEditors...)...)}
+}
+
+func (_c *MockScrapperClient_PutTgChatIdTemplate_Call) Run(run func(ctx context.Context, id int64, body scrapperclient.UpdateTemplateRequest, reqEditors ...scrapperclient.RequestEditorFn)) *MockScrapperClient_PutTgChatIdTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]scrapperclient.RequestEditorFn, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(scrapperclient.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(int64), args[2].(scrapperclient.UpdateTemplateRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockScrapperClient_PutTgChatIdTemplate_Call) Return(_a0 *http.Response, _a1 error) *MockScrapperClient_PutTgChatIdTemplate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockScrapperClient_PutTgChatIdTemplate_Call) RunAndReturn(run func(context.Context, int64, scrapperclient.UpdateTemplateRequest, ...scrapperclient.RequestEditorFn) (*http.Response, error)) *MockScrapperClient_PutTgChatIdTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockScrapperClient creates a new instance of MockScrapperClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockScrapperClient(t interface {
@@ -4,9 +4,12 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
-	scrapperapi "github.com/es-debug/backend-academy-2024-go-template/internal/api/openapi/v1/servers/scrapper"
 	mock "github.com/stretchr/testify/mock"
+
+	scrapperapi "github.com/es-debug/backend-academy-2024-go-template/internal/api/openapi/v1/servers/scrapper"
+	models "github.com/es-debug/backend-academy-2024-go-template/internal/domain/models"
 )
 
 // MockUpdaterStorage is an autogenerated mock type for the Storage type
@@ -22,29 +25,320 @@ func (_m *MockUpdaterStorage) EXPECT() *MockUpdaterStorage_Expecter {
 	return &MockUpdaterStorage_Expecter{mock: &_m.Mock}
 }
 
-// GetLinks provides a mock function with given fields: ctx, batch
-func (_m *MockUpdaterStorage) GetLinks(ctx context.Context, batch uint64) ([]scrapperapi.LinkResponse, error) {
-	ret := _m.Called(ctx, batch)
+// GetInstantSubscribers provides a mock function with given fields: ctx, linkID
+func (_m *MockUpdaterStorage) GetInstantSubscribers(ctx context.Context, linkID int64) ([]models.Subscriber, error) {
+	ret := _m.Called(ctx, linkID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInstantSubscribers")
+	}
+
+	var r0 []models.Subscriber
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]models.Subscriber, error)); ok {
+		return rf(ctx, linkID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []models.Subscriber); ok {
+		r0 = rf(ctx, linkID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Subscriber)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, linkID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUpdaterStorage_GetInstantSubscribers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInstantSubscribers'
+type MockUpdaterStorage_GetInstantSubscribers_Call struct {
+	*mock.Call
+}
+
+// GetInstantSubscribers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - linkID int64
+func (_e *MockUpdaterStorage_Expecter) GetInstantSubscribers(ctx interface{}, linkID interface{}) *MockUpdaterStorage_GetInstantSubscribers_Call {
+	return &MockUpdaterStorage_GetInstantSubscribers_Call{Call: _e.mock.On("GetInstantSubscribers", ctx, linkID)}
+}
+
+func (_c *MockUpdaterStorage_GetInstantSubscribers_Call) Run(run func(ctx context.Context, linkID int64)) *MockUpdaterStorage_GetInstantSubscribers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockUpdaterStorage_GetInstantSubscribers_Call) Return(_a0 []models.Subscriber, _a1 error) *MockUpdaterStorage_GetInstantSubscribers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUpdaterStorage_GetInstantSubscribers_Call) RunAndReturn(run func(context.Context, int64) ([]models.Subscriber, error)) *MockUpdaterStorage_GetInstantSubscribers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLinkCursor provides a mock function with given fields: ctx, linkID
+func (_m *MockUpdaterStorage) GetLinkCursor(ctx context.Context, linkID int64) (models.Cursor, error) {
+	ret := _m.Called(ctx, linkID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLinkCursor")
+	}
+
+	var r0 models.Cursor
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (models.Cursor, error)); ok {
+		return rf(ctx, linkID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) models.Cursor); ok {
+		r0 = rf(ctx, linkID)
+	} else {
+		r0 = ret.Get(0).(models.Cursor)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, linkID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUpdaterStorage_GetLinkCursor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLinkCursor'
+type MockUpdaterStorage_GetLinkCursor_Call struct {
+	*mock.Call
+}
+
+// GetLinkCursor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - linkID int64
+func (_e *MockUpdaterStorage_Expecter) GetLinkCursor(ctx interface{}, linkID interface{}) *MockUpdaterStorage_GetLinkCursor_Call {
+	return &MockUpdaterStorage_GetLinkCursor_Call{Call: _e.mock.On("GetLinkCursor", ctx, linkID)}
+}
+
+func (_c *MockUpdaterStorage_GetLinkCursor_Call) Run(run func(ctx context.Context, linkID int64)) *MockUpdaterStorage_GetLinkCursor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockUpdaterStorage_GetLinkCursor_Call) Return(_a0 models.Cursor, _a1 error) *MockUpdaterStorage_GetLinkCursor_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUpdaterStorage_GetLinkCursor_Call) RunAndReturn(run func(context.Context, int64) (models.Cursor, error)) *MockUpdaterStorage_GetLinkCursor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLinkSchedule provides a mock function with given fields: ctx, linkID
+func (_m *MockUpdaterStorage) GetLinkSchedule(ctx context.Context, linkID int64) (models.Schedule, error) {
+	ret := _m.Called(ctx, linkID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLinkSchedule")
+	}
+
+	var r0 models.Schedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (models.Schedule, error)); ok {
+		return rf(ctx, linkID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) models.Schedule); ok {
+		r0 = rf(ctx, linkID)
+	} else {
+		r0 = ret.Get(0).(models.Schedule)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, linkID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUpdaterStorage_GetLinkSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLinkSchedule'
+type MockUpdaterStorage_GetLinkSchedule_Call struct {
+	*mock.Call
+}
+
+// GetLinkSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - linkID int64
+func (_e *MockUpdaterStorage_Expecter) GetLinkSchedule(ctx interface{}, linkID interface{}) *MockUpdaterStorage_GetLinkSchedule_Call {
+	return &MockUpdaterStorage_GetLinkSchedule_Call{Call: _e.mock.On("GetLinkSchedule", ctx, linkID)}
+}
+
+func (_c *MockUpdaterStorage_GetLinkSchedule_Call) Run(run func(ctx context.Context, linkID int64)) *MockUpdaterStorage_GetLinkSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockUpdaterStorage_GetLinkSchedule_Call) Return(_a0 models.Schedule, _a1 error) *MockUpdaterStorage_GetLinkSchedule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUpdaterStorage_GetLinkSchedule_Call) RunAndReturn(run func(context.Context, int64) (models.Schedule, error)) *MockUpdaterStorage_GetLinkSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingUpdates provides a mock function with given fields: ctx, linkID, cursor
+func (_m *MockUpdaterStorage) GetPendingUpdates(ctx context.Context, linkID int64, cursor models.Cursor) ([]models.Update, error) {
+	ret := _m.Called(ctx, linkID, cursor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingUpdates")
+	}
+
+	var r0 []models.Update
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.Cursor) ([]models.Update, error)); ok {
+		return rf(ctx, linkID, cursor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.Cursor) []models.Update); ok {
+		r0 = rf(ctx, linkID, cursor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Update)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, models.Cursor) error); ok {
+		r1 = rf(ctx, linkID, cursor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUpdaterStorage_GetPendingUpdates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingUpdates'
+type MockUpdaterStorage_GetPendingUpdates_Call struct {
+	*mock.Call
+}
+
+// GetPendingUpdates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - linkID int64
+//   - cursor models.Cursor
+func (_e *MockUpdaterStorage_Expecter) GetPendingUpdates(ctx interface{}, linkID interface{}, cursor interface{}) *MockUpdaterStorage_GetPendingUpdates_Call {
+	return &MockUpdaterStorage_GetPendingUpdates_Call{Call: _e.mock.On("GetPendingUpdates", ctx, linkID, cursor)}
+}
+
+func (_c *MockUpdaterStorage_GetPendingUpdates_Call) Run(run func(ctx context.Context, linkID int64, cursor models.Cursor)) *MockUpdaterStorage_GetPendingUpdates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(models.Cursor))
+	})
+	return _c
+}
+
+func (_c *MockUpdaterStorage_GetPendingUpdates_Call) Return(_a0 []models.Update, _a1 error) *MockUpdaterStorage_GetPendingUpdates_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUpdaterStorage_GetPendingUpdates_Call) RunAndReturn(run func(context.Context, int64, models.Cursor) ([]models.Update, error)) *MockUpdaterStorage_GetPendingUpdates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSubscriptionCursor provides a mock function with given fields: ctx, chatID, linkID
+func (_m *MockUpdaterStorage) GetSubscriptionCursor(ctx context.Context, chatID int64, linkID int64) (models.Cursor, error) {
+	ret := _m.Called(ctx, chatID, linkID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubscriptionCursor")
+	}
+
+	var r0 models.Cursor
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (models.Cursor, error)); ok {
+		return rf(ctx, chatID, linkID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) models.Cursor); ok {
+		r0 = rf(ctx, chatID, linkID)
+	} else {
+		r0 = ret.Get(0).(models.Cursor)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, chatID, linkID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUpdaterStorage_GetSubscriptionCursor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSubscriptionCursor'
+type MockUpdaterStorage_GetSubscriptionCursor_Call struct {
+	*mock.Call
+}
+
+// GetSubscriptionCursor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+//   - linkID int64
+func (_e *MockUpdaterStorage_Expecter) GetSubscriptionCursor(ctx interface{}, chatID interface{}, linkID interface{}) *MockUpdaterStorage_GetSubscriptionCursor_Call {
+	return &MockUpdaterStorage_GetSubscriptionCursor_Call{Call: _e.mock.On("GetSubscriptionCursor", ctx, chatID, linkID)}
+}
+
+func (_c *MockUpdaterStorage_GetSubscriptionCursor_Call) Run(run func(ctx context.Context, chatID int64, linkID int64)) *MockUpdaterStorage_GetSubscriptionCursor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockUpdaterStorage_GetSubscriptionCursor_Call) Return(_a0 models.Cursor, _a1 error) *MockUpdaterStorage_GetSubscriptionCursor_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUpdaterStorage_GetSubscriptionCursor_Call) RunAndReturn(run func(context.Context, int64, int64) (models.Cursor, error)) *MockUpdaterStorage_GetSubscriptionCursor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LeaseLinks provides a mock function with given fields: ctx, batch, ttl
+func (_m *MockUpdaterStorage) LeaseLinks(ctx context.Context, batch uint64, ttl time.Duration) ([]scrapperapi.LinkResponse, error) {
+	ret := _m.Called(ctx, batch, ttl)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLinks")
+		panic("no return value specified for LeaseLinks")
 	}
 
 	var r0 []scrapperapi.LinkResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]scrapperapi.LinkResponse, error)); ok {
-		return rf(ctx, batch)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, time.Duration) ([]scrapperapi.LinkResponse, error)); ok {
+		return rf(ctx, batch, ttl)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint64) []scrapperapi.LinkResponse); ok {
-		r0 = rf(ctx, batch)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, time.Duration) []scrapperapi.LinkResponse); ok {
+		r0 = rf(ctx, batch, ttl)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]scrapperapi.LinkResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
-		r1 = rf(ctx, batch)
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, time.Duration) error); ok {
+		r1 = rf(ctx, batch, ttl)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -52,31 +346,138 @@ func (_m *MockUpdaterStorage) GetLinks(ctx context.Context, batch uint64) ([]scr
 	return r0, r1
 }
 
-// MockUpdaterStorage_GetLinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLinks'
-type MockUpdaterStorage_GetLinks_Call struct {
+// MockUpdaterStorage_LeaseLinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LeaseLinks'
+type MockUpdaterStorage_LeaseLinks_Call struct {
 	*mock.Call
 }
 
-// GetLinks is a helper method to define mock.On call
+// LeaseLinks is a helper method to define mock.On call
 //   - ctx context.Context
 //   - batch uint64
-func (_e *MockUpdaterStorage_Expecter) GetLinks(ctx interface{}, batch interface{}) *MockUpdaterStorage_GetLinks_Call {
-	return &MockUpdaterStorage_GetLinks_Call{Call: _e.mock.On("GetLinks", ctx, batch)}
+//   - ttl time.Duration
+func (_e *MockUpdaterStorage_Expecter) LeaseLinks(ctx interface{}, batch interface{}, ttl interface{}) *MockUpdaterStorage_LeaseLinks_Call {
+	return &MockUpdaterStorage_LeaseLinks_Call{Call: _e.mock.On("LeaseLinks", ctx, batch, ttl)}
+}
+
+func (_c *MockUpdaterStorage_LeaseLinks_Call) Run(run func(ctx context.Context, batch uint64, ttl time.Duration)) *MockUpdaterStorage_LeaseLinks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockUpdaterStorage_LeaseLinks_Call) Return(_a0 []scrapperapi.LinkResponse, _a1 error) *MockUpdaterStorage_LeaseLinks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUpdaterStorage_LeaseLinks_Call) RunAndReturn(run func(context.Context, uint64, time.Duration) ([]scrapperapi.LinkResponse, error)) *MockUpdaterStorage_LeaseLinks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkLinkChecked provides a mock function with given fields: ctx, linkID, result
+func (_m *MockUpdaterStorage) MarkLinkChecked(ctx context.Context, linkID int64, result models.CheckResult) error {
+	ret := _m.Called(ctx, linkID, result)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkLinkChecked")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.CheckResult) error); ok {
+		r0 = rf(ctx, linkID, result)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUpdaterStorage_MarkLinkChecked_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkLinkChecked'
+type MockUpdaterStorage_MarkLinkChecked_Call struct {
+	*mock.Call
+}
+
+// MarkLinkChecked is a helper method to define mock.On call
+//   - ctx context.Context
+//   - linkID int64
+//   - result models.CheckResult
+func (_e *MockUpdaterStorage_Expecter) MarkLinkChecked(ctx interface{}, linkID interface{}, result interface{}) *MockUpdaterStorage_MarkLinkChecked_Call {
+	return &MockUpdaterStorage_MarkLinkChecked_Call{Call: _e.mock.On("MarkLinkChecked", ctx, linkID, result)}
+}
+
+func (_c *MockUpdaterStorage_MarkLinkChecked_Call) Run(run func(ctx context.Context, linkID int64, result models.CheckResult)) *MockUpdaterStorage_MarkLinkChecked_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(models.CheckResult))
+	})
+	return _c
+}
+
+func (_c *MockUpdaterStorage_MarkLinkChecked_Call) Return(_a0 error) *MockUpdaterStorage_MarkLinkChecked_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUpdaterStorage_MarkLinkChecked_Call) RunAndReturn(run func(context.Context, int64, models.CheckResult) error) *MockUpdaterStorage_MarkLinkChecked_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveUpdates provides a mock function with given fields: ctx, linkID, updates
+func (_m *MockUpdaterStorage) SaveUpdates(ctx context.Context, linkID int64, updates []models.Update) (int, error) {
+	ret := _m.Called(ctx, linkID, updates)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveUpdates")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []models.Update) (int, error)); ok {
+		return rf(ctx, linkID, updates)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []models.Update) int); ok {
+		r0 = rf(ctx, linkID, updates)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, []models.Update) error); ok {
+		r1 = rf(ctx, linkID, updates)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUpdaterStorage_SaveUpdates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveUpdates'
+type MockUpdaterStorage_SaveUpdates_Call struct {
+	*mock.Call
+}
+
+// SaveUpdates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - linkID int64
+//   - updates []models.Update
+func (_e *MockUpdaterStorage_Expecter) SaveUpdates(ctx interface{}, linkID interface{}, updates interface{}) *MockUpdaterStorage_SaveUpdates_Call {
+	return &MockUpdaterStorage_SaveUpdates_Call{Call: _e.mock.On("SaveUpdates", ctx, linkID, updates)}
 }
 
-func (_c *MockUpdaterStorage_GetLinks_Call) Run(run func(ctx context.Context, batch uint64)) *MockUpdaterStorage_GetLinks_Call {
+func (_c *MockUpdaterStorage_SaveUpdates_Call) Run(run func(ctx context.Context, linkID int64, updates []models.Update)) *MockUpdaterStorage_SaveUpdates_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uint64))
+		run(args[0].(context.Context), args[1].(int64), args[2].([]models.Update))
 	})
 	return _c
 }
 
-func (_c *MockUpdaterStorage_GetLinks_Call) Return(_a0 []scrapperapi.LinkResponse, _a1 error) *MockUpdaterStorage_GetLinks_Call {
+func (_c *MockUpdaterStorage_SaveUpdates_Call) Return(_a0 int, _a1 error) *MockUpdaterStorage_SaveUpdates_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockUpdaterStorage_GetLinks_Call) RunAndReturn(run func(context.Context, uint64) ([]scrapperapi.LinkResponse, error)) *MockUpdaterStorage_GetLinks_Call {
+func (_c *MockUpdaterStorage_SaveUpdates_Call) RunAndReturn(run func(context.Context, int64, []models.Update) (int, error)) *MockUpdaterStorage_SaveUpdates_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -176,6 +577,55 @@ func (_c *MockUpdaterStorage_UpdateLinkActivity_Call) RunAndReturn(run func(cont
 	return _c
 }
 
+// UpdateSubscriptionCursor provides a mock function with given fields: ctx, chatID, linkID, cursor
+func (_m *MockUpdaterStorage) UpdateSubscriptionCursor(ctx context.Context, chatID int64, linkID int64, cursor models.Cursor) error {
+	ret := _m.Called(ctx, chatID, linkID, cursor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSubscriptionCursor")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, models.Cursor) error); ok {
+		r0 = rf(ctx, chatID, linkID, cursor)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUpdaterStorage_UpdateSubscriptionCursor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSubscriptionCursor'
+type MockUpdaterStorage_UpdateSubscriptionCursor_Call struct {
+	*mock.Call
+}
+
+// UpdateSubscriptionCursor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+//   - linkID int64
+//   - cursor models.Cursor
+func (_e *MockUpdaterStorage_Expecter) UpdateSubscriptionCursor(ctx interface{}, chatID interface{}, linkID interface{}, cursor interface{}) *MockUpdaterStorage_UpdateSubscriptionCursor_Call {
+	return &MockUpdaterStorage_UpdateSubscriptionCursor_Call{Call: _e.mock.On("UpdateSubscriptionCursor", ctx, chatID, linkID, cursor)}
+}
+
+func (_c *MockUpdaterStorage_UpdateSubscriptionCursor_Call) Run(run func(ctx context.Context, chatID int64, linkID int64, cursor models.Cursor)) *MockUpdaterStorage_UpdateSubscriptionCursor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(models.Cursor))
+	})
+	return _c
+}
+
+func (_c *MockUpdaterStorage_UpdateSubscriptionCursor_Call) Return(_a0 error) *MockUpdaterStorage_UpdateSubscriptionCursor_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUpdaterStorage_UpdateSubscriptionCursor_Call) RunAndReturn(run func(context.Context, int64, int64, models.Cursor) error) *MockUpdaterStorage_UpdateSubscriptionCursor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockUpdaterStorage creates a new instance of MockUpdaterStorage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockUpdaterStorage(t interface {
@@ -0,0 +1,31 @@
+package lru_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/pkg/lru"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	cache := lru.New[string, int](2)
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+
+	value, ok := cache.Get("a")
+	require.True(t, ok)
+	require.Equal(t, 1, value)
+
+	cache.Put("c", 3)
+
+	_, ok = cache.Get("b")
+	require.False(t, ok, "least recently used entry is evicted")
+
+	cache.Put("a", 10)
+
+	value, ok = cache.Get("a")
+	require.True(t, ok)
+	require.Equal(t, 10, value)
+	require.Equal(t, 2, cache.Len())
+}
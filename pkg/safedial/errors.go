@@ -0,0 +1,9 @@
+package safedial
+
+import "fmt"
+
+type safedialErr struct{ msg string }
+
+func (e safedialErr) Error() string { return fmt.Sprintf("error: %s", e.msg) }
+
+var ErrForbiddenAddress = safedialErr{msg: "address is not on the public internet"}
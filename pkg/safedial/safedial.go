@@ -0,0 +1,70 @@
+package safedial
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// reserved are ranges that are neither loopback, private nor link-local
+// by the netip predicates, but still must not be reached from the outside.
+var reserved = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// Public reports whether an address belongs to the public internet.
+func Public(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	if !addr.IsValid() || addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return false
+	}
+
+	for _, prefix := range reserved {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Control is a net.Dialer control refusing connections to non-public addresses.
+// It runs after name resolution, so a host resolving to a private address is caught too.
+func Control(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("safedial: %q: %w", address, err)
+	}
+
+	if !Public(addrPort.Addr()) {
+		return fmt.Errorf("safedial: %s: %w", addrPort.Addr(), ErrForbiddenAddress)
+	}
+
+	return nil
+}
+
+// Transport is the default transport dialing public addresses only. Proxies are
+// not used, since the check would otherwise apply to the proxy instead of the target.
+func Transport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	transport.Proxy = nil
+	transport.DialContext = (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   Control,
+	}).DialContext
+
+	return transport
+}
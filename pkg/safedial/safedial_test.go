@@ -0,0 +1,53 @@
+package safedial_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/pkg/safedial"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublic(t *testing.T) {
+	tests := map[string]struct {
+		addr   string
+		public bool
+	}{
+		"public ipv4":       {addr: "140.82.121.4", public: true},
+		"public ipv6":       {addr: "2606:4700::6810:84e5", public: true},
+		"loopback":          {addr: "127.0.0.1", public: false},
+		"ipv6 loopback":     {addr: "::1", public: false},
+		"private":           {addr: "10.1.2.3", public: false},
+		"private 192":       {addr: "192.168.0.10", public: false},
+		"metadata":          {addr: "169.254.169.254", public: false},
+		"unspecified":       {addr: "0.0.0.0", public: false},
+		"carrier nat":       {addr: "100.64.0.1", public: false},
+		"ipv4 mapped":       {addr: "::ffff:127.0.0.1", public: false},
+		"unique local ipv6": {addr: "fd00::1", public: false},
+		"link local ipv6":   {addr: "fe80::1", public: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, test.public, safedial.Public(netip.MustParseAddr(test.addr)))
+		})
+	}
+}
+
+func TestTransportRefusesLoopback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: safedial.Transport()}
+
+	resp, err := client.Get(server.URL)
+	if resp != nil {
+		resp.Body.Close()
+	}
+
+	require.ErrorIs(t, err, safedial.ErrForbiddenAddress)
+}
@@ -0,0 +1,9 @@
+package semver
+
+import "fmt"
+
+type semverErr struct{ msg string }
+
+func (e semverErr) Error() string { return fmt.Sprintf("error: %s", e.msg) }
+
+var ErrInvalidVersion = semverErr{msg: "not a semantic version"}
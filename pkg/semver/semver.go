@@ -0,0 +1,181 @@
+// Package semver parses the version-like names of releases and tags and orders them
+// by semantic versioning precedence. It is lenient about the shapes found in the wild:
+// a leading "v" or other prefix ("go1.22.0", "release-2.1"), missing minor and patch
+// numbers, and pre-release suffixes written without a hyphen ("1.22rc1").
+package semver
+
+import (
+	"strconv"
+	"strings"
+)
+
+type Level string
+
+const (
+	Major Level = "major"
+	Minor Level = "minor"
+	Patch Level = "patch"
+)
+
+type Version struct {
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+}
+
+func Parse(raw string) (Version, error) {
+	s := strings.TrimSpace(raw)
+
+	// Prefixes such as "v", "go" or "release-" precede the first digit.
+	start := strings.IndexFunc(s, isDigit)
+	if start < 0 {
+		return Version{}, ErrInvalidVersion
+	}
+
+	if strings.ContainsFunc(s[:start], isDigitOrDot) {
+		return Version{}, ErrInvalidVersion
+	}
+
+	s = s[start:]
+
+	// Build metadata does not take part in precedence.
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s = s[:i]
+	}
+
+	core, prerelease := s, ""
+
+	if i := strings.IndexFunc(s, func(r rune) bool { return !isDigitOrDot(r) }); i >= 0 {
+		core, prerelease = s[:i], strings.TrimLeft(s[i:], "-.")
+
+		if prerelease == "" {
+			return Version{}, ErrInvalidVersion
+		}
+	}
+
+	parts := strings.Split(core, ".")
+	if len(parts) > 3 {
+		return Version{}, ErrInvalidVersion
+	}
+
+	numbers := make([]int, 3)
+
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return Version{}, ErrInvalidVersion
+		}
+
+		numbers[i] = n
+	}
+
+	return Version{
+		Major:      numbers[0],
+		Minor:      numbers[1],
+		Patch:      numbers[2],
+		Prerelease: prerelease,
+	}, nil
+}
+
+func (v Version) IsPrerelease() bool {
+	return v.Prerelease != ""
+}
+
+func (v Version) String() string {
+	s := strconv.Itoa(v.Major) + "." + strconv.Itoa(v.Minor) + "." + strconv.Itoa(v.Patch)
+	if v.IsPrerelease() {
+		s += "-" + v.Prerelease
+	}
+
+	return s
+}
+
+// Compare returns -1, 0 or +1 as v precedes, equals or follows o.
+func (v Version) Compare(o Version) int {
+	if c := v.CompareCore(o); c != 0 {
+		return c
+	}
+
+	switch {
+	case v.Prerelease == o.Prerelease:
+		return 0
+	case v.Prerelease == "":
+		return 1
+	case o.Prerelease == "":
+		return -1
+	}
+
+	return comparePrerelease(v.Prerelease, o.Prerelease)
+}
+
+// CompareCore compares major, minor and patch only.
+func (v Version) CompareCore(o Version) int {
+	for _, pair := range [][2]int{{v.Major, o.Major}, {v.Minor, o.Minor}, {v.Patch, o.Patch}} {
+		if c := compareInts(pair[0], pair[1]); c != 0 {
+			return c
+		}
+	}
+
+	return 0
+}
+
+// Bump tells which part of prev was raised to reach next.
+// A pre-release is judged by the release it leads to, so 2.0.0-rc1 after 1.4.2 is a major bump.
+func Bump(prev, next Version) Level {
+	switch {
+	case next.Major != prev.Major:
+		return Major
+	case next.Minor != prev.Minor:
+		return Minor
+	}
+
+	return Patch
+}
+
+// comparePrerelease orders dot-separated identifiers: numeric ones numerically
+// and below alphanumeric ones, a shorter list first when all shared ones are equal.
+func comparePrerelease(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+
+		switch {
+		case aErr == nil && bErr == nil:
+			if c := compareInts(an, bn); c != 0 {
+				return c
+			}
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(as[i], bs[i]); c != 0 {
+				return c
+			}
+		}
+	}
+
+	return compareInts(len(as), len(bs))
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func isDigitOrDot(r rune) bool {
+	return isDigit(r) || r == '.'
+}
@@ -0,0 +1,115 @@
+package semver_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/pkg/semver"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		raw      string
+		expected semver.Version
+		wantErr  bool
+	}{
+		"plain": {
+			raw:      "1.2.3",
+			expected: semver.Version{Major: 1, Minor: 2, Patch: 3},
+		},
+		"v prefix with pre-release and build": {
+			raw:      "v2.0.0-rc.1+build.5",
+			expected: semver.Version{Major: 2, Prerelease: "rc.1"},
+		},
+		"go tag": {
+			raw:      "go1.22.0",
+			expected: semver.Version{Major: 1, Minor: 22},
+		},
+		"missing patch with glued pre-release": {
+			raw:      "go1.23rc2",
+			expected: semver.Version{Major: 1, Minor: 23, Prerelease: "rc2"},
+		},
+		"named prefix": {
+			raw:      "release-4",
+			expected: semver.Version{Major: 4},
+		},
+		"no digits": {
+			raw:     "nightly",
+			wantErr: true,
+		},
+		"too many parts": {
+			raw:     "1.2.3.4",
+			wantErr: true,
+		},
+		"empty part": {
+			raw:     "1..2",
+			wantErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, err := semver.Parse(test.raw)
+
+			if test.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.10.0",
+		"2.0.0",
+	}
+
+	for i := 1; i < len(ordered); i++ {
+		lower, err := semver.Parse(ordered[i-1])
+		require.NoError(t, err)
+
+		higher, err := semver.Parse(ordered[i])
+		require.NoError(t, err)
+
+		require.Equal(t, -1, lower.Compare(higher), "%s < %s", ordered[i-1], ordered[i])
+		require.Equal(t, 1, higher.Compare(lower), "%s > %s", ordered[i], ordered[i-1])
+		require.Equal(t, 0, higher.Compare(higher))
+	}
+}
+
+func TestBump(t *testing.T) {
+	tests := map[string]struct {
+		prev, next string
+		expected   semver.Level
+	}{
+		"major":               {prev: "1.4.2", next: "2.0.0", expected: semver.Major},
+		"minor":               {prev: "1.4.2", next: "1.5.0", expected: semver.Minor},
+		"patch":               {prev: "1.4.2", next: "1.4.3", expected: semver.Patch},
+		"pre-release of next": {prev: "1.4.2", next: "2.0.0-rc1", expected: semver.Major},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			prev, err := semver.Parse(test.prev)
+			require.NoError(t, err)
+
+			next, err := semver.Parse(test.next)
+			require.NoError(t, err)
+
+			require.Equal(t, test.expected, semver.Bump(prev, next))
+		})
+	}
+}